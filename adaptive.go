@@ -0,0 +1,49 @@
+package gocrc
+
+import "sync"
+
+// AdjustFunc receives each finished worker's Result and the current concurrency limit, and returns
+// the new limit. See WithAdaptiveLimit.
+type AdjustFunc[T any] func(res Result[T], limit int) int
+
+// WithAdaptiveLimit caps how many workers run at once, starting at initial, and lets adjust retune
+// the cap after every finished worker, e.g. backing off when errors spike. Calls to adjust are
+// serialized; limits below 1 are raised to 1. Lowering the limit never interrupts running workers:
+// new ones are held back until enough of them have finished. See AIMD for a ready-made controller.
+func WithAdaptiveLimit[T any](initial int, adjust AdjustFunc[T]) Option[T] {
+	return func(o *options[T]) {
+		limit := max(initial, 1)
+		o.adaptive = &adaptiveLimit[T]{sem: newWeighted(int64(limit)), limit: limit, adjust: adjust}
+	}
+}
+
+// AIMD returns an additive-increase/multiplicative-decrease controller for WithAdaptiveLimit:
+// every success raises the limit by one up to maxLimit, every failure halves it down to minLimit.
+func AIMD[T any](minLimit, maxLimit int) AdjustFunc[T] {
+	return func(res Result[T], limit int) int {
+		if res.Err != nil {
+			return max(limit/2, minLimit)
+		}
+		return min(limit+1, maxLimit)
+	}
+}
+
+type adaptiveLimit[T any] struct {
+	sem    *weighted
+	mu     sync.Mutex
+	limit  int
+	adjust AdjustFunc[T]
+}
+
+// done releases the slot held by the worker that produced res and applies the adjusted limit.
+func (a *adaptiveLimit[T]) done(res Result[T]) {
+	if a.adjust != nil {
+		a.mu.Lock()
+		if limit := max(a.adjust(res, a.limit), 1); limit != a.limit {
+			a.limit = limit
+			a.sem.Resize(int64(limit))
+		}
+		a.mu.Unlock()
+	}
+	a.sem.Release(1)
+}
@@ -0,0 +1,82 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithAdaptiveLimit(t *testing.T) {
+	t.Run("lowered_limit", func(t *testing.T) {
+		ctx := context.Background()
+		var active, crowded int32
+
+		w := func(ctx context.Context) (int, error) {
+			if atomic.AddInt32(&active, 1) > 1 {
+				atomic.AddInt32(&crowded, 1)
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+			return 0, nil
+		}
+		workers := make([]Worker[int], 9)
+		for i := range workers {
+			workers[i] = w
+		}
+
+		var calls int32
+		adjust := func(res Result[int], limit int) int {
+			atomic.AddInt32(&calls, 1)
+			return 1
+		}
+
+		if _, err := NoRaceWith(ctx, []Option[int]{WithAdaptiveLimit(3, adjust)}, workers...); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		// Only the initial batch of 3 may overlap; afterwards workers run one at a time.
+		if c := atomic.LoadInt32(&crowded); c > 2 {
+			t.Errorf("expected at most 2 overlapping starts, got %d", c)
+		}
+		if c := atomic.LoadInt32(&calls); c != 9 {
+			t.Errorf("expected adjust to be called 9 times, got %d", c)
+		}
+	})
+
+	t.Run("cancelled_while_waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		block := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		results, err := NoRaceWith(ctx, []Option[int]{WithAdaptiveLimit[int](1, nil)}, block, block)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		for _, res := range results {
+			if !errors.Is(res.Err, context.DeadlineExceeded) {
+				t.Errorf("expected deadline exceeded, got %v", res.Err)
+			}
+		}
+	})
+}
+
+func TestAIMD(t *testing.T) {
+	adjust := AIMD[int](2, 5)
+
+	if got := adjust(Result[int]{}, 4); got != 5 {
+		t.Errorf("success: expected 5, got %d", got)
+	}
+	if got := adjust(Result[int]{}, 5); got != 5 {
+		t.Errorf("success at max: expected 5, got %d", got)
+	}
+	if got := adjust(Result[int]{Err: errors.New("boom")}, 5); got != 2 {
+		t.Errorf("failure: expected 2, got %d", got)
+	}
+	if got := adjust(Result[int]{Err: errors.New("boom")}, 3); got != 2 {
+		t.Errorf("failure at min: expected 2, got %d", got)
+	}
+}
@@ -0,0 +1,50 @@
+package gocrc
+
+import (
+	"context"
+	"slices"
+)
+
+// Any runs multiple workers concurrently and reports true as soon as one Result satisfies pred,
+// cancelling the rest. If every worker completes without a match, it reports false together with a
+// MultiError of the failed workers, or nil if none failed.
+func Any[T any](ctx context.Context, pred func(Result[T]) bool, workers ...Worker[T]) (bool, error) {
+	matched, _, err := short(ctx, pred, true, workers)
+	return matched, err
+}
+
+// All runs multiple workers concurrently and reports false as soon as one Result fails pred,
+// cancelling the rest; the error is then that worker's error. If every Result satisfies pred, it
+// reports true together with a MultiError of the failed workers, or nil if none failed.
+func All[T any](ctx context.Context, pred func(Result[T]) bool, workers ...Worker[T]) (bool, error) {
+	mismatched, res, err := short(ctx, pred, false, workers)
+	if mismatched {
+		return false, res.Err
+	}
+	return true, err
+}
+
+// short runs the workers until one Result's pred equals want, in which case it returns true and that
+// Result. Otherwise it waits for every worker and returns the aggregate error.
+func short[T any](ctx context.Context, pred func(Result[T]) bool, want bool, workers []Worker[T]) (bool, Result[T], error) {
+	shortCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	resultCh := spawn(shortCtx, workers)
+	results := make([]Result[T], 0, len(workers))
+	for range workers {
+		select {
+		case res := <-resultCh:
+			if pred(res) == want {
+				cancel(ErrRaceLost)
+				return true, res, nil
+			}
+			results = append(results, res)
+		case <-ctx.Done():
+			return false, Result[T]{Index: IndexCancelled, Err: ctx.Err()}, ctx.Err()
+		}
+	}
+
+	slices.SortFunc(results, func(a, b Result[T]) int { return a.Index - b.Index })
+	return false, Result[T]{}, collectErrors(results)
+}
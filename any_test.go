@@ -0,0 +1,89 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAny(t *testing.T) {
+	healthy := func(r Result[int]) bool { return r.Err == nil && r.Value == 200 }
+
+	t.Run("match_cancels_rest", func(t *testing.T) {
+		ctx := context.Background()
+		var cancelled int32
+
+		ok, err := Any(ctx, healthy,
+			func(ctx context.Context) (int, error) { return 500, nil },
+			func(ctx context.Context) (int, error) {
+				time.Sleep(10 * time.Millisecond)
+				return 200, nil
+			},
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				atomic.AddInt32(&cancelled, 1)
+				return 0, ctx.Err()
+			},
+		)
+		if !ok || err != nil {
+			t.Errorf("expected (true, nil), got (%v, %v)", ok, err)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		if atomic.LoadInt32(&cancelled) != 1 {
+			t.Errorf("expected the pending worker to be cancelled")
+		}
+	})
+
+	t.Run("no_match_aggregates", func(t *testing.T) {
+		ctx := context.Background()
+		down := errors.New("down")
+
+		ok, err := Any(ctx, healthy,
+			func(ctx context.Context) (int, error) { return 0, down },
+			func(ctx context.Context) (int, error) { return 503, nil },
+		)
+		if ok {
+			t.Errorf("expected false")
+		}
+		if !errors.Is(err, down) {
+			t.Errorf("expected the worker error to be aggregated, got %v", err)
+		}
+	})
+}
+
+func TestAll(t *testing.T) {
+	healthy := func(r Result[int]) bool { return r.Err == nil && r.Value == 200 }
+
+	t.Run("all_match", func(t *testing.T) {
+		ctx := context.Background()
+		ok, err := All(ctx, healthy,
+			func(ctx context.Context) (int, error) { return 200, nil },
+			func(ctx context.Context) (int, error) { return 200, nil },
+		)
+		if !ok || err != nil {
+			t.Errorf("expected (true, nil), got (%v, %v)", ok, err)
+		}
+	})
+
+	t.Run("first_mismatch", func(t *testing.T) {
+		ctx := context.Background()
+		down := errors.New("down")
+
+		ok, err := All(ctx, healthy,
+			func(ctx context.Context) (int, error) { return 0, down },
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			},
+		)
+		if ok {
+			t.Errorf("expected false")
+		}
+		if err != down {
+			t.Errorf("expected %v, got %v", down, err)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by a worker wrapped with WithCircuitBreaker while the circuit is open.
+var ErrCircuitOpen = errors.New("gocrc: circuit breaker is open")
+
+// BreakerConfig controls WithCircuitBreaker.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that trips the circuit open.
+	// Values below 1 default to 1.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a single trial call is let through.
+	Cooldown time.Duration
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type breaker struct {
+	cfg BreakerConfig
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+// WithCircuitBreaker wraps w with a circuit breaker shared by every invocation of the returned worker.
+// After cfg.FailureThreshold consecutive failures the circuit opens and calls fail fast with
+// ErrCircuitOpen. Once cfg.Cooldown has elapsed, one trial call is let through (half-open): its success
+// closes the circuit, its failure opens it again. A panicking call counts as a failure and the panic
+// propagates, e.g. to become a *PanicError in NoRace. Failures caused by the caller's own context ending
+// are not counted.
+func WithCircuitBreaker[T any](cfg BreakerConfig, w Worker[T]) Worker[T] {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 1
+	}
+	b := &breaker{cfg: cfg}
+
+	return func(ctx context.Context) (T, error) {
+		if !b.allow() {
+			var zero T
+			return zero, ErrCircuitOpen
+		}
+
+		// Recorded in a defer so that a panicking call, a trial call in particular, counts as a
+		// failure instead of leaving the circuit half-open forever. The panic carries on.
+		returned := false
+		defer func() {
+			if !returned {
+				b.record(errBreakerPanic, false)
+			}
+		}()
+		val, err := w(ctx)
+		returned = true
+		b.record(err, ctx.Err() != nil)
+		return val, err
+	}
+}
+
+// errBreakerPanic is recorded by WithCircuitBreaker for a call that panicked.
+var errBreakerPanic = errors.New("gocrc: worker panicked")
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cfg.Cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// A trial call is already in flight.
+		return false
+	}
+	return true
+}
+
+func (b *breaker) record(err error, ctxDone bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case err == nil:
+		b.state = breakerClosed
+		b.failures = 0
+	case ctxDone:
+		// Not the downstream's fault; let a half-open trial be retried.
+		if b.state == breakerHalfOpen {
+			b.state = breakerOpen
+		}
+	default:
+		b.failures++
+		if b.state == breakerHalfOpen || b.failures >= b.cfg.FailureThreshold {
+			b.state = breakerOpen
+			b.openedAt = time.Now()
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	t.Run("trips_and_recovers", func(t *testing.T) {
+		var healthy atomic.Bool
+		var calls int32
+		w := WithCircuitBreaker(BreakerConfig{FailureThreshold: 2, Cooldown: 30 * time.Millisecond},
+			func(ctx context.Context) (string, error) {
+				atomic.AddInt32(&calls, 1)
+				if healthy.Load() {
+					return "ok", nil
+				}
+				return "", errors.New("dead")
+			})
+
+		ctx := context.Background()
+		for range 2 {
+			if _, err := w(ctx); err == nil || errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("expected a downstream failure, got %v", err)
+			}
+		}
+		if _, err := w(ctx); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected ErrCircuitOpen, got %v", err)
+		}
+		if c := atomic.LoadInt32(&calls); c != 2 {
+			t.Errorf("expected the open circuit to skip the call, got %d calls", c)
+		}
+
+		time.Sleep(40 * time.Millisecond)
+		healthy.Store(true)
+		if v, err := w(ctx); err != nil || v != "ok" {
+			t.Fatalf("expected the half-open trial to succeed, got %v, %v", v, err)
+		}
+		if _, err := w(ctx); err != nil {
+			t.Errorf("expected the circuit to be closed again, got %v", err)
+		}
+	})
+
+	t.Run("failed_trial_reopens", func(t *testing.T) {
+		w := WithCircuitBreaker(BreakerConfig{FailureThreshold: 1, Cooldown: 20 * time.Millisecond},
+			func(ctx context.Context) (int, error) { return 0, errors.New("dead") })
+
+		ctx := context.Background()
+		_, _ = w(ctx)
+		time.Sleep(30 * time.Millisecond)
+		if _, err := w(ctx); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected the trial call to run, got %v", err)
+		}
+		if _, err := w(ctx); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("expected the circuit to reopen, got %v", err)
+		}
+	})
+
+	t.Run("panicking_trial_reopens", func(t *testing.T) {
+		var panics atomic.Bool
+		w := WithCircuitBreaker(BreakerConfig{FailureThreshold: 1, Cooldown: 20 * time.Millisecond},
+			func(ctx context.Context) (int, error) {
+				if panics.Load() {
+					panic("boom")
+				}
+				return 0, errors.New("dead")
+			})
+
+		ctx := context.Background()
+		_, _ = w(ctx)
+		time.Sleep(30 * time.Millisecond)
+		panics.Store(true)
+		results, _ := NoRace(ctx, w)
+		var perr *PanicError
+		if !errors.As(results[0].Err, &perr) {
+			t.Fatalf("expected the trial's panic to propagate, got %v", results[0].Err)
+		}
+
+		panics.Store(false)
+		if _, err := w(ctx); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("expected the panicking trial to reopen the circuit, got %v", err)
+		}
+		time.Sleep(30 * time.Millisecond)
+		if _, err := w(ctx); errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("expected a new trial after the cooldown, got %v", err)
+		}
+	})
+
+	t.Run("single_trial_when_half_open", func(t *testing.T) {
+		release := make(chan struct{})
+		var fail atomic.Bool
+		fail.Store(true)
+		w := WithCircuitBreaker(BreakerConfig{FailureThreshold: 1, Cooldown: 10 * time.Millisecond},
+			func(ctx context.Context) (int, error) {
+				if fail.Load() {
+					return 0, errors.New("dead")
+				}
+				<-release
+				return 1, nil
+			})
+
+		ctx := context.Background()
+		_, _ = w(ctx)
+		time.Sleep(20 * time.Millisecond)
+		fail.Store(false)
+
+		var wg sync.WaitGroup
+		var open int32
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := w(ctx); errors.Is(err, ErrCircuitOpen) {
+					atomic.AddInt32(&open, 1)
+				}
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if o := atomic.LoadInt32(&open); o != 4 {
+			t.Errorf("expected 4 calls rejected during the trial, got %d", o)
+		}
+	})
+}
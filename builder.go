@@ -0,0 +1,99 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrInputClosed is returned by StreamBuilder.Add once CloseInput has been called.
+var ErrInputClosed = errors.New("gocrc: stream input is closed")
+
+// StreamBuilder is a Stream whose workers are added while it runs, e.g. by a server accepting work
+// over its lifetime. Results are emitted on Results as workers finish, and the channel is closed once
+// the input is closed and every added worker has finished.
+type StreamBuilder[T any] struct {
+	ctx context.Context
+	out chan Result[T]
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	mu        sync.Mutex
+	closed    bool
+	next      int
+	completed int
+}
+
+// NewStreamBuilder returns a StreamBuilder running its workers under ctx, with at most limit of them
+// at a time (limit <= 0 means no limit) and up to bufferSize Results waiting to be received. As with
+// StreamLimit, a worker holds its slot until its Result is received or buffered, so a slow consumer
+// makes Add block.
+func NewStreamBuilder[T any](ctx context.Context, limit, bufferSize int) *StreamBuilder[T] {
+	b := &StreamBuilder[T]{ctx: ctx, out: make(chan Result[T], max(bufferSize, 0))}
+	if limit > 0 {
+		b.sem = make(chan struct{}, limit)
+	}
+	return b
+}
+
+// Add starts w once a slot is free, blocking until then. Its Result takes the next Index, in the
+// order workers were admitted. Add fails with ErrInputClosed after CloseInput, or with ctx.Err() if
+// the context is done while waiting, in which case w does not run.
+func (b *StreamBuilder[T]) Add(w Worker[T]) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrInputClosed
+	}
+	// Counted before waiting, so that CloseInput cannot close the channel under this worker.
+	b.wg.Add(1)
+	b.mu.Unlock()
+
+	if b.sem != nil {
+		select {
+		case b.sem <- struct{}{}:
+		case <-b.ctx.Done():
+			b.wg.Done()
+			return b.ctx.Err()
+		}
+	}
+
+	b.mu.Lock()
+	index := b.next
+	b.next++
+	b.mu.Unlock()
+
+	go func() {
+		defer b.wg.Done()
+		if b.sem != nil {
+			defer func() { <-b.sem }()
+		}
+		res := invoke(b.ctx, index, w)
+		b.mu.Lock()
+		res.CompletionOrder = b.completed
+		b.completed++
+		b.mu.Unlock()
+		b.out <- res
+	}()
+	return nil
+}
+
+// CloseInput stops accepting workers. Results is closed once the workers already added have finished.
+// It is safe to call CloseInput more than once.
+func (b *StreamBuilder[T]) CloseInput() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	go func() {
+		b.wg.Wait()
+		close(b.out)
+	}()
+}
+
+// Results returns the channel on which the Results are emitted. It must be drained until closed.
+func (b *StreamBuilder[T]) Results() <-chan Result[T] {
+	return b.out
+}
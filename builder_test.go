@@ -0,0 +1,82 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStreamBuilder(t *testing.T) {
+	t.Run("add_while_running", func(t *testing.T) {
+		b := NewStreamBuilder[int](context.Background(), 0, 0)
+		go func() {
+			for i := range 5 {
+				b.Add(func(ctx context.Context) (int, error) { return i, nil })
+				time.Sleep(time.Millisecond)
+			}
+			b.CloseInput()
+		}()
+
+		sum, n := 0, 0
+		for res := range b.Results() {
+			sum += res.Value
+			n++
+		}
+		if n != 5 || sum != 10 {
+			t.Errorf("expected 5 results summing to 10, got %d summing to %d", n, sum)
+		}
+		if err := b.Add(func(ctx context.Context) (int, error) { return 0, nil }); err != ErrInputClosed {
+			t.Errorf("expected ErrInputClosed, got %v", err)
+		}
+	})
+
+	t.Run("limit_and_backpressure", func(t *testing.T) {
+		b := NewStreamBuilder[int](context.Background(), 1, 0)
+		var started int32
+		w := func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&started, 1)
+			return 1, nil
+		}
+
+		added := make(chan struct{})
+		go func() {
+			defer close(added)
+			b.Add(w)
+			b.Add(w) // Blocks until the first Result is received
+		}()
+
+		time.Sleep(30 * time.Millisecond)
+		if s := atomic.LoadInt32(&started); s != 1 {
+			t.Errorf("expected 1 worker started without a consumer, got %d", s)
+		}
+		<-b.Results()
+		<-added
+		b.CloseInput()
+		if n := Drain(b.Results()); n != 1 {
+			t.Errorf("expected 1 more result, got %d", n)
+		}
+	})
+
+	t.Run("cancelled_while_waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		b := NewStreamBuilder[int](ctx, 1, 0)
+		block := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+
+		if err := b.Add(block); err != nil {
+			t.Fatalf("expected the first Add to succeed, got %v", err)
+		}
+		if err := b.Add(block); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the deadline while waiting for a slot, got %v", err)
+		}
+		b.CloseInput()
+		if n := Drain(b.Results()); n != 1 {
+			t.Errorf("expected 1 result, got %d", n)
+		}
+	})
+}
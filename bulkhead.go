@@ -0,0 +1,62 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownPartition is returned by Bulkhead.Run for a partition that was not configured.
+var ErrUnknownPartition = errors.New("gocrc: unknown bulkhead partition")
+
+// Bulkhead isolates workers of different categories behind separate concurrency limits, so that a
+// saturated partition, e.g. a slow database, cannot take the slots of another, e.g. a cache.
+// Limits are shared by every Run call on the same partition.
+type Bulkhead[T any] struct {
+	partitions map[string]*weighted
+}
+
+// NewBulkhead returns a Bulkhead with one partition per entry of limits, allowing that many workers
+// of the partition to run at once. Limits below 1 are treated as 1.
+func NewBulkhead[T any](limits map[string]int) *Bulkhead[T] {
+	b := &Bulkhead[T]{partitions: make(map[string]*weighted, len(limits))}
+	for name, limit := range limits {
+		b.partitions[name] = newWeighted(int64(max(limit, 1)))
+	}
+	return b
+}
+
+// Run runs the workers within the limit of partition, admitting them in index order, and follows the
+// contract of NoRace. Workers still waiting for a slot when the context is done do not run and
+// report ctx.Err().
+func (b *Bulkhead[T]) Run(ctx context.Context, partition string, workers ...Worker[T]) ([]Result[T], error) {
+	sem, ok := b.partitions[partition]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPartition, partition)
+	}
+
+	results := make([]Result[T], len(workers))
+	ctx = withTotal(ctx, len(workers))
+	var wg sync.WaitGroup
+	for i := range workers {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			for j := i; j < len(workers); j++ {
+				results[j] = Result[T]{Err: err, Index: j}
+			}
+			break
+		}
+
+		index := i
+		worker := workers[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer sem.Release(1)
+			results[index] = invoke(ctx, index, worker)
+		}()
+	}
+
+	wg.Wait()
+	return results, collectErrors(results)
+}
@@ -0,0 +1,70 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBulkhead(t *testing.T) {
+	b := NewBulkhead[int](map[string]int{"db": 1, "cache": 2})
+
+	t.Run("partitions_isolated", func(t *testing.T) {
+		ctx := context.Background()
+		release := make(chan struct{})
+		dbDone := make(chan struct{})
+		stuck := func(ctx context.Context) (int, error) {
+			<-release
+			return 0, nil
+		}
+		go func() {
+			defer close(dbDone)
+			b.Run(ctx, "db", stuck, stuck)
+		}()
+
+		fast := func(ctx context.Context) (int, error) { return 1, nil }
+		start := time.Now()
+		results, err := b.Run(ctx, "cache", fast, fast, fast)
+		if err != nil || len(results) != 3 {
+			t.Errorf("expected the cache workers to succeed, got %d results, %v", len(results), err)
+		}
+		if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+			t.Errorf("expected cache workers not to wait for db, took %v", elapsed)
+		}
+
+		close(release)
+		<-dbDone
+	})
+
+	t.Run("shared_limit", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		block := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		held := make(chan struct{})
+		go func() {
+			defer close(held)
+			b.Run(ctx, "db", block)
+		}()
+		time.Sleep(5 * time.Millisecond)
+
+		var ran bool
+		results, _ := b.Run(ctx, "db", func(ctx context.Context) (int, error) {
+			ran = true
+			return 1, nil
+		})
+		<-held
+		if ran || !errors.Is(results[0].Err, context.DeadlineExceeded) {
+			t.Errorf("expected the second call to wait for the slot until the deadline, got %+v", results[0])
+		}
+	})
+
+	t.Run("unknown_partition", func(t *testing.T) {
+		if _, err := b.Run(context.Background(), "queue"); !errors.Is(err, ErrUnknownPartition) {
+			t.Errorf("expected ErrUnknownPartition, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package gocrc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithBurstLimit caps how many workers run at once at limit, but lets up to burst more start for a
+// short while, so that a wave of workers finishing and starting in quick succession is not held back
+// by a hard limit. Precisely:
+//
+//   - While fewer than limit workers run, a worker starts right away.
+//   - While between limit and limit+burst-1 run, a worker starts only during a burst. A burst begins
+//     when a worker starts above the limit, and admits workers above the limit for window from then.
+//   - A burst ends once no more than limit workers run, however long that takes. A new one can begin
+//     only window after that, so workers start above the limit at most half of the time.
+//   - At no time do more than limit+burst workers run.
+//
+// Workers started during a burst are never interrupted when it ends; new ones are held back until the
+// count is back below limit. Limits below 1 are raised to 1; a burst or window <= 0 is a plain limit.
+func WithBurstLimit[T any](limit, burst int, window time.Duration) Option[T] {
+	return func(o *options[T]) {
+		b := &burstLimit{limit: max(limit, 1), window: window, changed: make(chan struct{})}
+		if window > 0 {
+			b.burst = max(burst, 0)
+		}
+		o.burst = b
+	}
+}
+
+type burstLimit struct {
+	limit  int
+	burst  int
+	window time.Duration
+
+	mu         sync.Mutex
+	running    int
+	burstStart time.Time // zero outside a burst
+	burstEnd   time.Time
+	changed    chan struct{} // closed and replaced whenever running drops
+}
+
+// acquire waits until the worker may start, or fails with ctx.Err() if the context is done first.
+func (b *burstLimit) acquire(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		ok, retry := b.admit(time.Now())
+		changed := b.changed
+		b.mu.Unlock()
+		if ok {
+			return nil
+		}
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if retry > 0 {
+			timer = time.NewTimer(retry)
+			expired = timer.C
+		}
+		select {
+		case <-changed:
+		case <-expired:
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// admit counts a starting worker in if the rules of WithBurstLimit allow it at now. Otherwise, if only
+// the pause between bursts is missing, retry is how long until it is over. b.mu must be held.
+func (b *burstLimit) admit(now time.Time) (ok bool, retry time.Duration) {
+	switch {
+	case b.running < b.limit:
+	case b.running >= b.limit+b.burst:
+		return false, 0
+	case !b.burstStart.IsZero():
+		if now.Sub(b.burstStart) >= b.window {
+			return false, 0
+		}
+	case !b.burstEnd.IsZero() && now.Sub(b.burstEnd) < b.window:
+		return false, b.window - now.Sub(b.burstEnd)
+	default:
+		b.burstStart = now
+	}
+	b.running++
+	return true, 0
+}
+
+// release frees the slot of a finished worker, ending the burst once no more than limit run.
+func (b *burstLimit) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.running--
+	if b.running <= b.limit && !b.burstStart.IsZero() {
+		b.burstStart = time.Time{}
+		b.burstEnd = time.Now()
+	}
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
@@ -0,0 +1,80 @@
+package gocrc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithBurstLimit(t *testing.T) {
+	// peakOf runs the workers with opts and reports how many ran at once at most.
+	peakOf := func(opts []Option[int], n int, d time.Duration) int32 {
+		var inFlight, peak int32
+		workers := make([]Worker[int], n)
+		for i := range workers {
+			workers[i] = func(ctx context.Context) (int, error) {
+				cur := atomic.AddInt32(&inFlight, 1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+						break
+					}
+				}
+				time.Sleep(d)
+				atomic.AddInt32(&inFlight, -1)
+				return 0, nil
+			}
+		}
+		NoRaceWith(context.Background(), opts, workers...)
+		return atomic.LoadInt32(&peak)
+	}
+
+	t.Run("burst_above_limit", func(t *testing.T) {
+		opts := []Option[int]{WithBurstLimit[int](2, 2, time.Second)}
+		if p := peakOf(opts, 8, 20*time.Millisecond); p != 4 {
+			t.Errorf("expected a peak of limit+burst = 4, got %d", p)
+		}
+	})
+
+	t.Run("no_burst_without_window", func(t *testing.T) {
+		opts := []Option[int]{WithBurstLimit[int](2, 2, 0)}
+		if p := peakOf(opts, 6, 10*time.Millisecond); p != 2 {
+			t.Errorf("expected a plain limit of 2, got %d", p)
+		}
+	})
+
+	t.Run("burst_expires", func(t *testing.T) {
+		b := &burstLimit{limit: 1, burst: 2, window: 20 * time.Millisecond, changed: make(chan struct{})}
+		now := time.Now()
+		if ok, _ := b.admit(now); !ok {
+			t.Fatalf("expected the first worker within the limit")
+		}
+		if ok, _ := b.admit(now); !ok {
+			t.Fatalf("expected the second worker to start a burst")
+		}
+		if ok, _ := b.admit(now.Add(30 * time.Millisecond)); ok {
+			t.Errorf("expected no start above the limit once the burst outlasted its window")
+		}
+
+		b.release()
+		b.release()
+		if b.running != 0 || b.burstStart != (time.Time{}) {
+			t.Fatalf("expected the burst to end once back within the limit")
+		}
+		b.admit(time.Now())
+		if ok, retry := b.admit(time.Now()); ok || retry <= 0 {
+			t.Errorf("expected a pause before the next burst, got ok=%v retry=%v", ok, retry)
+		}
+	})
+
+	t.Run("cancelled_while_waiting", func(t *testing.T) {
+		b := &burstLimit{limit: 1, changed: make(chan struct{})}
+		b.acquire(context.Background())
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := b.acquire(ctx); err != context.DeadlineExceeded {
+			t.Errorf("expected the deadline, got %v", err)
+		}
+	})
+}
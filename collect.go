@@ -0,0 +1,305 @@
+package gocrc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Map runs fn over each item concurrently with at most limit calls in flight (limit <= 0 means no limit)
+// and returns the outputs in input order. If any call fails or is never started because the context was
+// cancelled, the partial outputs are returned together with a MultiError describing the failed indices.
+// Map always collects every error; see MapWith for fail-fast.
+func Map[In, Out any](ctx context.Context, limit int, items []In, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	return MapWith(ctx, limit, MapCollectAll, items, fn)
+}
+
+// MapMode decides how MapWith reacts to a failing call.
+type MapMode int
+
+const (
+	// MapCollectAll runs every item regardless of failures and reports them all in a MultiError.
+	// It is the default, used by Map, and suits best-effort bulk operations.
+	MapCollectAll MapMode = iota
+	// MapFailFast cancels the remaining calls at the first failure and returns that failure's error,
+	// for expensive items where one failure invalidates the whole output.
+	MapFailFast
+)
+
+// MapWith is like Map but lets the caller choose whether the first failure cancels the rest.
+// With MapFailFast the outputs that completed before the cancellation are still returned, calls in
+// flight see context.Cause report ErrSiblingFailed, no further calls are started, and the error is
+// the first failure's own, not a MultiError.
+func MapWith[In, Out any](ctx context.Context, limit int, mode MapMode, items []In, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	var first error
+	if mode == MapFailFast {
+		var cancel context.CancelCauseFunc
+		ctx, cancel = context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		var once sync.Once
+		inner := fn
+		fn = func(ctx context.Context, item In) (Out, error) {
+			out, err := inner(ctx, item)
+			if err != nil && ctx.Err() == nil {
+				once.Do(func() {
+					first = err
+					cancel(fmt.Errorf("%w: %w", ErrSiblingFailed, err))
+				})
+			}
+			return out, err
+		}
+	}
+
+	workers := make([]Worker[Out], len(items))
+	for i := range items {
+		item := items[i]
+		workers[i] = func(ctx context.Context) (Out, error) {
+			return fn(ctx, item)
+		}
+	}
+
+	results := runLimited(ctx, limit, workers)
+	outs := make([]Out, len(results))
+	for i, r := range results {
+		outs[i] = r.Value
+	}
+	if first != nil {
+		return outs, first
+	}
+	return outs, collectErrors(results)
+}
+
+// BatchMode decides how Batch reacts to a chunk containing failures.
+type BatchMode int
+
+const (
+	// BatchContinue processes every chunk regardless of failures.
+	BatchContinue BatchMode = iota
+	// BatchStopOnError stops after the first chunk containing a failure.
+	BatchStopOnError
+)
+
+// Batch runs fn over the items in consecutive chunks of chunkSize, each chunk fully concurrent and
+// fully completed before the next starts, which bounds peak concurrency and memory.
+// Outputs come back in input order across all chunks; failures are reported through a MultiError
+// while the partial outputs are still returned. Batch processes every chunk; see BatchWith.
+func Batch[In, Out any](ctx context.Context, chunkSize int, items []In, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	return BatchWith(ctx, chunkSize, BatchContinue, items, fn)
+}
+
+// BatchWith is like Batch but lets the caller choose whether to stop after a failing chunk.
+// When it stops, items of the chunks never started carry ErrUnfinished in the MultiError.
+func BatchWith[In, Out any](ctx context.Context, chunkSize int, mode BatchMode, items []In, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+
+	outs := make([]Out, len(items))
+	var failures []Result[Out]
+	for start := 0; start < len(items); start += chunkSize {
+		end := min(start+chunkSize, len(items))
+		chunk, err := Map(ctx, 0, items[start:end], fn)
+		copy(outs[start:], chunk)
+
+		if merr, ok := err.(*MultiError[Out]); ok {
+			for _, r := range merr.Results {
+				r.Index += start
+				failures = append(failures, r)
+			}
+			if mode == BatchStopOnError {
+				for i := end; i < len(items); i++ {
+					failures = append(failures, Result[Out]{Err: ErrUnfinished, Index: i})
+				}
+				break
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return outs, &MultiError[Out]{Results: failures}
+	}
+	return outs, nil
+}
+
+// Filter runs pred over each item concurrently with at most limit calls in flight (limit <= 0 means no limit)
+// and returns the items for which it reported true, in input order. Items whose predicate failed are left
+// out and described by the returned MultiError. Once the context is done no further predicates are started.
+func Filter[T any](ctx context.Context, limit int, items []T, pred func(context.Context, T) (bool, error)) ([]T, error) {
+	workers := make([]Worker[bool], len(items))
+	for i := range items {
+		item := items[i]
+		workers[i] = func(ctx context.Context) (bool, error) {
+			return pred(ctx, item)
+		}
+	}
+
+	results := runLimited(ctx, limit, workers)
+	var kept []T
+	for i, r := range results {
+		if r.Err == nil && r.Value {
+			kept = append(kept, items[i])
+		}
+	}
+	return kept, collectErrors(results)
+}
+
+// FlatMap runs fn over each item concurrently with at most limit calls in flight (limit <= 0 means no limit)
+// and concatenates the returned slices in input order, so item 0's outputs precede item 1's. Outputs of
+// failed calls are left out and the failures are described by the returned MultiError.
+func FlatMap[In, Out any](ctx context.Context, limit int, items []In, fn func(context.Context, In) ([]Out, error)) ([]Out, error) {
+	workers := make([]Worker[[]Out], len(items))
+	for i := range items {
+		item := items[i]
+		workers[i] = func(ctx context.Context) ([]Out, error) {
+			return fn(ctx, item)
+		}
+	}
+
+	results := runLimited(ctx, limit, workers)
+	var outs []Out
+	for _, r := range results {
+		if r.Err == nil {
+			outs = append(outs, r.Value...)
+		}
+	}
+	return outs, collectErrors(results)
+}
+
+// Reduce folds the results into a single value, starting from initial. fn sees failed results too,
+// so errors can be counted in the same pass.
+func Reduce[T, Acc any](results []Result[T], initial Acc, fn func(Acc, Result[T]) Acc) Acc {
+	acc := initial
+	for _, r := range results {
+		acc = fn(acc, r)
+	}
+	return acc
+}
+
+// ReduceStream is like Reduce but folds results as they arrive on ch, e.g. from Stream.
+// It returns once ch is closed.
+func ReduceStream[T, Acc any](ch <-chan Result[T], initial Acc, fn func(Acc, Result[T]) Acc) Acc {
+	acc := initial
+	for r := range ch {
+		acc = fn(acc, r)
+	}
+	return acc
+}
+
+// ForEach calls fn on each result in slice order, which for NoRace is index order, and stops at the
+// first error fn returns, returning it. Unlike Reduce it is meant for side effects, such as writing
+// the results to a store, where one failure should abort the rest.
+func ForEach[T any](results []Result[T], fn func(Result[T]) error) error {
+	for _, r := range results {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunSeq pulls workers from next until it reports false, running at most limit at a time (limit <= 0
+// means no limit), so that work sources too large or too costly to enumerate up front never have to be
+// held in memory. next is called from a single goroutine, only once a slot is free. Results are in pull
+// order, with a MultiError if any worker failed. If the context is done before next is exhausted,
+// pulling stops, the started workers are awaited, and the error wraps both ErrCancelled and ctx.Err().
+func RunSeq[T any](ctx context.Context, limit int, next func() (Worker[T], bool)) ([]Result[T], error) {
+	var sem chan struct{}
+	if limit > 0 {
+		sem = make(chan struct{}, limit)
+	}
+
+	var mu sync.Mutex
+	var results []Result[T]
+	var wg sync.WaitGroup
+	var stopped error
+	for index := 0; ; index++ {
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			stopped = err
+			break
+		}
+
+		worker, ok := next()
+		if !ok {
+			break
+		}
+		mu.Lock()
+		results = append(results, Result[T]{Index: index})
+		mu.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+			res := invoke(ctx, index, worker)
+			mu.Lock()
+			results[index] = res
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	if stopped != nil {
+		return results, fmt.Errorf("%w: %w", ErrCancelled, stopped)
+	}
+	return results, collectErrors(results)
+}
+
+// runLimited runs the workers with at most limit in flight (limit <= 0 means no limit) and waits for
+// all of them. Once the context is done no further workers are started; their Results carry ctx.Err().
+func runLimited[T any](ctx context.Context, limit int, workers []Worker[T]) []Result[T] {
+	results := make([]Result[T], len(workers))
+	ctx = withTotal(ctx, len(workers))
+	if limit <= 0 || limit > len(workers) {
+		limit = len(workers)
+	}
+
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := range workers {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			for j := i; j < len(workers); j++ {
+				results[j] = Result[T]{Err: err, Index: j}
+			}
+			break
+		}
+
+		index := i
+		worker := workers[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[index] = invoke(ctx, index, worker)
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+// collectErrors returns a MultiError of the failed results, or nil if none failed.
+func collectErrors[T any](results []Result[T]) error {
+	var errResults []Result[T]
+	for _, r := range results {
+		if r.Err != nil {
+			errResults = append(errResults, r)
+		}
+	}
+	if len(errResults) == 0 {
+		return nil
+	}
+	return &MultiError[T]{Results: errResults}
+}
@@ -0,0 +1,404 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("ordered_with_limit", func(t *testing.T) {
+		ctx := context.Background()
+		var inFlight, peak int32
+
+		items := []int{1, 2, 3, 4, 5, 6}
+		outs, err := Map(ctx, 2, items, func(ctx context.Context, n int) (string, error) {
+			cur := atomic.AddInt32(&inFlight, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return strconv.Itoa(n * n), nil
+		})
+
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		want := []string{"1", "4", "9", "16", "25", "36"}
+		for i := range want {
+			if outs[i] != want[i] {
+				t.Errorf("expected %v, got %v", want, outs)
+				break
+			}
+		}
+		if p := atomic.LoadInt32(&peak); p > 2 {
+			t.Errorf("expected at most 2 in flight, got %d", p)
+		}
+	})
+
+	t.Run("partial_on_error", func(t *testing.T) {
+		ctx := context.Background()
+		outs, err := Map(ctx, 0, []int{1, 0, 3}, func(ctx context.Context, n int) (int, error) {
+			if n == 0 {
+				return 0, errors.New("division by zero")
+			}
+			return 6 / n, nil
+		})
+
+		merr, ok := err.(*MultiError[int])
+		if !ok {
+			t.Fatalf("expected *MultiError[int], got %T", err)
+		}
+		if len(merr.Results) != 1 || merr.Results[0].Index != 1 {
+			t.Errorf("expected index 1 to fail, got %v", merr.Results)
+		}
+		if outs[0] != 6 || outs[2] != 2 {
+			t.Errorf("expected partial outputs, got %v", outs)
+		}
+	})
+
+	t.Run("cancel_stops_scheduling", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls int32
+
+		_, err := Map(ctx, 1, []int{1, 2, 3, 4}, func(ctx context.Context, n int) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			cancel()
+			return n, nil
+		})
+
+		if !errors.Is(err.(*MultiError[int]).Results[0].Err, context.Canceled) {
+			t.Errorf("expected unscheduled items to carry context.Canceled, got %v", err)
+		}
+		if c := atomic.LoadInt32(&calls); c != 1 {
+			t.Errorf("expected 1 call, got %d", c)
+		}
+	})
+}
+
+func TestMapWith(t *testing.T) {
+	boom := errors.New("boom")
+
+	t.Run("fail_fast", func(t *testing.T) {
+		var cause error
+		outs, err := MapWith(context.Background(), 0, MapFailFast, []int{1, 0, 3}, func(ctx context.Context, n int) (int, error) {
+			switch n {
+			case 0:
+				time.Sleep(10 * time.Millisecond)
+				return 0, boom
+			case 3:
+				<-ctx.Done()
+				cause = context.Cause(ctx)
+				return 0, ctx.Err()
+			}
+			return n * 10, nil
+		})
+
+		if err != boom {
+			t.Errorf("expected the first failure's error, got %v", err)
+		}
+		if !errors.Is(cause, ErrSiblingFailed) || !errors.Is(cause, boom) {
+			t.Errorf("expected the cause to wrap ErrSiblingFailed and boom, got %v", cause)
+		}
+		if outs[0] != 10 {
+			t.Errorf("expected the partial output of item 0, got %v", outs)
+		}
+	})
+
+	t.Run("fail_fast_stops_scheduling", func(t *testing.T) {
+		var calls int32
+		_, err := MapWith(context.Background(), 1, MapFailFast, []int{1, 2, 3}, func(ctx context.Context, n int) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return 0, boom
+		})
+
+		if err != boom {
+			t.Errorf("expected boom, got %v", err)
+		}
+		if c := atomic.LoadInt32(&calls); c != 1 {
+			t.Errorf("expected 1 call, got %d", c)
+		}
+	})
+
+	t.Run("collect_all", func(t *testing.T) {
+		_, err := MapWith(context.Background(), 1, MapCollectAll, []int{1, 2, 3}, func(ctx context.Context, n int) (int, error) {
+			return 0, boom
+		})
+
+		merr, ok := err.(*MultiError[int])
+		if !ok || len(merr.Results) != 3 {
+			t.Errorf("expected all 3 failures, got %v", err)
+		}
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	ctx := context.Background()
+	outs, err := FlatMap(ctx, 2, []int{3, 0, 2, -1}, func(ctx context.Context, n int) ([]int, error) {
+		if n < 0 {
+			return []int{99}, errors.New("negative")
+		}
+		time.Sleep(time.Duration(3-n) * time.Millisecond)
+		out := make([]int, n)
+		for i := range out {
+			out[i] = n
+		}
+		return out, nil
+	})
+
+	merr, ok := err.(*MultiError[[]int])
+	if !ok || len(merr.Results) != 1 || merr.Results[0].Index != 3 {
+		t.Fatalf("expected a MultiError for index 3, got %v", err)
+	}
+	want := []int{3, 3, 3, 2, 2}
+	if !slices.Equal(outs, want) {
+		t.Errorf("expected %v, got %v", want, outs)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("keeps_order", func(t *testing.T) {
+		ctx := context.Background()
+		kept, err := Filter(ctx, 2, []int{1, 2, 3, 4, 5, 6}, func(ctx context.Context, n int) (bool, error) {
+			time.Sleep(time.Duration(6-n) * time.Millisecond)
+			return n%2 == 0, nil
+		})
+
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if len(kept) != 3 || kept[0] != 2 || kept[1] != 4 || kept[2] != 6 {
+			t.Errorf("expected [2 4 6], got %v", kept)
+		}
+	})
+
+	t.Run("errors_with_survivors", func(t *testing.T) {
+		ctx := context.Background()
+		urls := []string{"ok-a", "bad", "ok-b"}
+		kept, err := Filter(ctx, 0, urls, func(ctx context.Context, u string) (bool, error) {
+			if u == "bad" {
+				return false, errors.New("unreachable")
+			}
+			return true, nil
+		})
+
+		merr, ok := err.(*MultiError[bool])
+		if !ok {
+			t.Fatalf("expected *MultiError[bool], got %T", err)
+		}
+		if merr.Results[0].Index != 1 {
+			t.Errorf("expected index 1 to fail, got %d", merr.Results[0].Index)
+		}
+		if len(kept) != 2 || kept[0] != "ok-a" || kept[1] != "ok-b" {
+			t.Errorf("expected survivors [ok-a ok-b], got %v", kept)
+		}
+	})
+}
+
+func TestReduce(t *testing.T) {
+	type tally struct{ sum, failed int }
+	fold := func(acc tally, r Result[int]) tally {
+		if r.Err != nil {
+			acc.failed++
+			return acc
+		}
+		acc.sum += r.Value
+		return acc
+	}
+
+	t.Run("slice", func(t *testing.T) {
+		results := []Result[int]{
+			{Value: 1, Index: 0},
+			{Err: errors.New("boom"), Index: 1},
+			{Value: 5, Index: 2},
+		}
+
+		got := Reduce(results, tally{}, fold)
+		if got.sum != 6 || got.failed != 1 {
+			t.Errorf("expected sum 6 and 1 failure, got %+v", got)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		ctx := context.Background()
+		ch := Stream(ctx,
+			func(ctx context.Context) (int, error) { return 2, nil },
+			func(ctx context.Context) (int, error) { return 3, nil },
+			func(ctx context.Context) (int, error) { return 0, errors.New("boom") },
+		)
+
+		got := ReduceStream(ch, tally{}, fold)
+		if got.sum != 5 || got.failed != 1 {
+			t.Errorf("expected sum 5 and 1 failure, got %+v", got)
+		}
+	})
+}
+
+func TestForEach(t *testing.T) {
+	results := []Result[int]{{Value: 1, Index: 0}, {Value: 2, Index: 1}, {Value: 3, Index: 2}}
+	errFull := errors.New("store full")
+
+	var seen []int
+	err := ForEach(results, func(r Result[int]) error {
+		seen = append(seen, r.Value)
+		if r.Value == 2 {
+			return errFull
+		}
+		return nil
+	})
+	if err != errFull {
+		t.Errorf("expected %v, got %v", errFull, err)
+	}
+	if !slices.Equal(seen, []int{1, 2}) {
+		t.Errorf("expected to stop after [1 2], saw %v", seen)
+	}
+
+	if err := ForEach(results, func(Result[int]) error { return nil }); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	t.Run("chunks_complete_in_sequence", func(t *testing.T) {
+		ctx := context.Background()
+		var inFlight, peak int32
+
+		items := []int{0, 1, 2, 3, 4, 5, 6}
+		outs, err := Batch(ctx, 3, items, func(ctx context.Context, n int) (int, error) {
+			cur := atomic.AddInt32(&inFlight, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return n * 10, nil
+		})
+
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		for i, o := range outs {
+			if o != i*10 {
+				t.Errorf("expected outputs in input order, got %v", outs)
+				break
+			}
+		}
+		if p := atomic.LoadInt32(&peak); p > 3 {
+			t.Errorf("expected at most 3 in flight, got %d", p)
+		}
+	})
+
+	failOnFour := func(ctx context.Context, n int) (int, error) {
+		if n == 4 {
+			return 0, errors.New("bad item")
+		}
+		return n, nil
+	}
+
+	t.Run("continue_after_error", func(t *testing.T) {
+		ctx := context.Background()
+		outs, err := Batch(ctx, 2, []int{0, 1, 2, 3, 4, 5, 6}, failOnFour)
+
+		merr, ok := err.(*MultiError[int])
+		if !ok {
+			t.Fatalf("expected *MultiError[int], got %T", err)
+		}
+		if len(merr.Results) != 1 || merr.Results[0].Index != 4 {
+			t.Errorf("expected global index 4 to fail, got %v", merr.Results)
+		}
+		if outs[6] != 6 {
+			t.Errorf("expected later chunks to run, got %v", outs)
+		}
+	})
+
+	t.Run("stop_on_error", func(t *testing.T) {
+		ctx := context.Background()
+		outs, err := BatchWith(ctx, 2, BatchStopOnError, []int{0, 1, 2, 3, 4, 5, 6}, failOnFour)
+
+		merr, ok := err.(*MultiError[int])
+		if !ok {
+			t.Fatalf("expected *MultiError[int], got %T", err)
+		}
+		indices := merr.Indices()
+		if len(indices) != 2 || indices[0] != 4 || indices[1] != 6 {
+			t.Errorf("expected indices [4 6], got %v", indices)
+		}
+		if merr.Results[1].Err != ErrUnfinished {
+			t.Errorf("expected unstarted items to be unfinished, got %v", merr.Results[1].Err)
+		}
+		if outs[5] != 5 || outs[6] != 0 {
+			t.Errorf("expected the failing chunk to complete and the next to be skipped, got %v", outs)
+		}
+	})
+}
+
+func TestRunSeq(t *testing.T) {
+	t.Run("pull_order_and_limit", func(t *testing.T) {
+		ctx := context.Background()
+		var active, crowded int32
+
+		pulled := 0
+		next := func() (Worker[int], bool) {
+			if pulled == 6 {
+				return nil, false
+			}
+			n := pulled
+			pulled++
+			return func(ctx context.Context) (int, error) {
+				if atomic.AddInt32(&active, 1) > 2 {
+					atomic.AddInt32(&crowded, 1)
+				}
+				defer atomic.AddInt32(&active, -1)
+				time.Sleep(time.Duration(6-n) * time.Millisecond)
+				return n * 10, nil
+			}, true
+		}
+
+		results, err := RunSeq(ctx, 2, next)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(results) != 6 {
+			t.Fatalf("expected 6 results, got %d", len(results))
+		}
+		for i, res := range results {
+			if res.Index != i || res.Value != i*10 {
+				t.Errorf("expected result %d to be %d, got %+v", i, i*10, res)
+			}
+		}
+		if c := atomic.LoadInt32(&crowded); c != 0 {
+			t.Errorf("expected at most 2 concurrent workers, exceeded %d times", c)
+		}
+	})
+
+	t.Run("cancel_stops_pulling", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		pulled := 0
+		next := func() (Worker[int], bool) {
+			pulled++
+			return func(ctx context.Context) (int, error) {
+				cancel()
+				return 1, nil
+			}, true
+		}
+
+		results, err := RunSeq(ctx, 1, next)
+		if !errors.Is(err, ErrCancelled) || !errors.Is(err, context.Canceled) {
+			t.Errorf("expected ErrCancelled wrapping context.Canceled, got %v", err)
+		}
+		if pulled != 1 || len(results) != 1 {
+			t.Errorf("expected a single pull, got %d pulls and %d results", pulled, len(results))
+		}
+	})
+}
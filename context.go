@@ -0,0 +1,64 @@
+package gocrc
+
+import "context"
+
+// workerKey is the context key under which the running worker's identity is stored. Being an
+// unexported type, it cannot collide with keys defined by other packages.
+type workerKey struct{}
+
+type workerInfo struct {
+	index int
+	name  string
+}
+
+// totalKey is the context key under which the size of the running batch is stored.
+type totalKey struct{}
+
+// correlationKey is the context key under which WithCorrelationID stores its ID.
+type correlationKey struct{}
+
+// withWorker derives a context identifying the worker at index. Values of ctx are preserved.
+func withWorker(ctx context.Context, index int, name string) context.Context {
+	return context.WithValue(ctx, workerKey{}, workerInfo{index: index, name: name})
+}
+
+// withTotal derives a context telling the workers of a batch how many workers it has.
+func withTotal(ctx context.Context, total int) context.Context {
+	return context.WithValue(ctx, totalKey{}, total)
+}
+
+// IndexFromContext returns the index of the worker running under ctx. The context handed to a worker
+// by this package carries it, alongside every value of the parent context. ok is false elsewhere.
+func IndexFromContext(ctx context.Context) (index int, ok bool) {
+	info, ok := ctx.Value(workerKey{}).(workerInfo)
+	return info.index, ok
+}
+
+// NameFromContext returns the name of the worker running under ctx, if it was given one.
+func NameFromContext(ctx context.Context) (name string, ok bool) {
+	info, ok := ctx.Value(workerKey{}).(workerInfo)
+	return info.name, ok && info.name != ""
+}
+
+// TotalFromContext returns the number of workers in the batch of the worker running under ctx, e.g. to
+// size buffers in proportion to it. It is set by the calls given their workers up front, such as NoRace,
+// Race, Stream and Map; ok is false elsewhere, including for RunSeq and StreamBuilder.
+func TotalFromContext(ctx context.Context) (total int, ok bool) {
+	total, ok = ctx.Value(totalKey{}).(int)
+	return total, ok
+}
+
+// WithCorrelationID returns a copy of ctx carrying id, e.g. the ID of the request that started the
+// work, so that workers and hooks can tie what they log back to it. Every context this package derives
+// for a worker, including those cancelled on a race win, a timeout or a sibling failure, keeps all values
+// of its parent, so an ID set before calling NoRace, Race or any other function is seen by every worker.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID returns the ID stored in ctx by WithCorrelationID. ok is false if there is none.
+// WithLogger adds it to every record under LogKeyCorrelationID.
+func CorrelationID(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(correlationKey{}).(string)
+	return id, ok
+}
@@ -0,0 +1,210 @@
+package gocrc
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerContext(t *testing.T) {
+	type requestKey struct{}
+	ctx := context.WithValue(context.Background(), requestKey{}, "req-1")
+
+	worker := func(ctx context.Context) (string, error) {
+		index, ok := IndexFromContext(ctx)
+		if !ok {
+			t.Errorf("expected an index in the worker context")
+		}
+		if ctx.Value(requestKey{}) != "req-1" {
+			t.Errorf("expected parent values to be preserved")
+		}
+		name, _ := NameFromContext(ctx)
+		return name + string(rune('0'+index)), nil
+	}
+
+	results, err := NoRaceNamed(ctx,
+		NamedWorker[string]{Name: "a", Worker: worker},
+		NamedWorker[string]{Name: "b", Worker: worker},
+	)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if results[0].Value != "a0" || results[1].Value != "b1" {
+		t.Errorf("expected [a0 b1], got [%s %s]", results[0].Value, results[1].Value)
+	}
+
+	for res := range Stream(ctx, worker, worker) {
+		if res.Value != string(rune('0'+res.Index)) {
+			t.Errorf("expected the stream worker to see index %d, got %q", res.Index, res.Value)
+		}
+	}
+
+	if _, ok := IndexFromContext(ctx); ok {
+		t.Errorf("expected no index outside a worker")
+	}
+	if _, ok := NameFromContext(ctx); ok {
+		t.Errorf("expected no name outside a worker")
+	}
+}
+
+func TestTotalFromContext(t *testing.T) {
+	ctx := context.Background()
+	worker := func(ctx context.Context) (int, error) {
+		total, ok := TotalFromContext(ctx)
+		if !ok {
+			t.Errorf("expected a total in the worker context")
+		}
+		return total, nil
+	}
+
+	results, _ := NoRace(ctx, worker, worker, worker)
+	for _, r := range results {
+		if r.Value != 3 {
+			t.Errorf("expected NoRace workers to see 3, got %d", r.Value)
+		}
+	}
+	if res, _ := Race(ctx, worker, worker); res.Value != 2 {
+		t.Errorf("expected Race workers to see 2, got %d", res.Value)
+	}
+	for res := range Stream(ctx, worker) {
+		if res.Value != 1 {
+			t.Errorf("expected Stream workers to see 1, got %d", res.Value)
+		}
+	}
+
+	nested := func(ctx context.Context) (int, error) {
+		inner, _ := NoRace(ctx, worker, worker)
+		return inner[0].Value, nil
+	}
+	if results, _ := NoRace(ctx, nested); results[0].Value != 2 {
+		t.Errorf("expected a nested batch to see its own total, got %d", results[0].Value)
+	}
+
+	if _, ok := TotalFromContext(ctx); ok {
+		t.Errorf("expected no total outside a worker")
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	ctx := WithCorrelationID(context.Background(), "req-1")
+	worker := func(ctx context.Context) (string, error) {
+		id, _ := CorrelationID(ctx)
+		return id, nil
+	}
+	slow := func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		id, _ := CorrelationID(ctx)
+		return id, ctx.Err()
+	}
+
+	res, all, _ := RaceVerbose(ctx, worker, slow)
+	if res.Value != "req-1" {
+		t.Errorf("expected the race winner to see the ID, got %q", res.Value)
+	}
+	for _, r := range all {
+		if r.Value != "req-1" {
+			t.Errorf("expected the cancelled loser to see the ID too, got %+v", r)
+		}
+	}
+	results, _ := NoRaceFailFast(ctx, worker, worker)
+	for _, r := range results {
+		if r.Value != "req-1" {
+			t.Errorf("expected every worker to see the ID, got %q", r.Value)
+		}
+	}
+
+	if _, ok := CorrelationID(context.Background()); ok {
+		t.Errorf("expected no ID on a bare context")
+	}
+}
+
+func TestTotalFromContextEntryPoints(t *testing.T) {
+	pass := func(Result[int]) bool { return true }
+	unordered := func(a, b int) bool { return false }
+	type batch func(ctx context.Context, ws []Worker[int])
+
+	for _, tc := range []struct {
+		name string
+		run  batch
+	}{
+		{"NoRace", func(ctx context.Context, ws []Worker[int]) { NoRace(ctx, ws...) }},
+		{"NoRaceWith", func(ctx context.Context, ws []Worker[int]) { NoRaceWith(ctx, nil, ws...) }},
+		{"NoRaceWithStats", func(ctx context.Context, ws []Worker[int]) { NoRaceWithStats(ctx, nil, ws...) }},
+		{"NoRaceFailFast", func(ctx context.Context, ws []Worker[int]) { NoRaceFailFast(ctx, ws...) }},
+		{"NoRaceMaxErrors", func(ctx context.Context, ws []Worker[int]) { NoRaceMaxErrors(ctx, 1, ws...) }},
+		{"NoRaceDeadline", func(ctx context.Context, ws []Worker[int]) { NoRaceDeadline(ctx, time.Now().Add(time.Second), ws...) }},
+		{"NoRaceSorted", func(ctx context.Context, ws []Worker[int]) { NoRaceSorted(ctx, unordered, ws...) }},
+		{"NoRaceDefault", func(ctx context.Context, ws []Worker[int]) { NoRaceDefault(ctx, ws...) }},
+		{"NoRaceStrict", func(ctx context.Context, ws []Worker[int]) { NoRaceStrict(ctx, ws...) }},
+		{"NoRaceWeighted", func(ctx context.Context, ws []Worker[int]) { NoRaceWeighted(ctx, 1, make([]int64, len(ws)), ws...) }},
+		{"NoRaceInto", func(ctx context.Context, ws []Worker[int]) { NoRaceInto(ctx, make(chan Result[int], len(ws)), ws...) }},
+		{"NoRaceAsync", func(ctx context.Context, ws []Worker[int]) {
+			ch, cancel := NoRaceAsync(ctx, ws...)
+			defer cancel()
+			<-ch
+		}},
+		{"Sequential", func(ctx context.Context, ws []Worker[int]) { Sequential(ctx, ws...) }},
+		{"Race", func(ctx context.Context, ws []Worker[int]) { Race(ctx, ws...) }},
+		{"RaceWith", func(ctx context.Context, ws []Worker[int]) { RaceWith(ctx, nil, ws...) }},
+		{"RaceStrict", func(ctx context.Context, ws []Worker[int]) { RaceStrict(ctx, ws...) }},
+		{"RaceSuccess", func(ctx context.Context, ws []Worker[int]) { RaceSuccess(ctx, ws...) }},
+		{"RaceDetailed", func(ctx context.Context, ws []Worker[int]) { RaceDetailed(ctx, ws...) }},
+		{"RaceVerbose", func(ctx context.Context, ws []Worker[int]) { RaceVerbose(ctx, ws...) }},
+		{"RaceTimed", func(ctx context.Context, ws []Worker[int]) { RaceTimed(ctx, ws...) }},
+		{"RaceOrTimeout", func(ctx context.Context, ws []Worker[int]) { RaceOrTimeout(ctx, time.Second, ws...) }},
+		{"RaceInterim", func(ctx context.Context, ws []Worker[int]) { RaceInterim(ctx, ws...) }},
+		{"RaceQuorum", func(ctx context.Context, ws []Worker[int]) { RaceQuorum(ctx, 1, ws...) }},
+		{"StartRace", func(ctx context.Context, ws []Worker[int]) { StartRace(ctx, ws...).Wait() }},
+		{"First", func(ctx context.Context, ws []Worker[int]) { First(ctx, 1, ws...) }},
+		{"Any", func(ctx context.Context, ws []Worker[int]) { Any(ctx, pass, ws...) }},
+		{"All", func(ctx context.Context, ws []Worker[int]) { All(ctx, pass, ws...) }},
+		{"Run", func(ctx context.Context, ws []Worker[int]) { Run(ctx, Config[int]{Workers: ws}) }},
+		{"Stream", func(ctx context.Context, ws []Worker[int]) { Drain(Stream(ctx, ws...)) }},
+		{"StreamLimit", func(ctx context.Context, ws []Worker[int]) { Drain(StreamLimit(ctx, 1, 0, ws...)) }},
+		{"StreamOrdered", func(ctx context.Context, ws []Worker[int]) { Drain(StreamOrdered(ctx, ws...)) }},
+		{"Bulkhead.Run", func(ctx context.Context, ws []Worker[int]) {
+			NewBulkhead[int](map[string]int{"p": 1}).Run(ctx, "p", ws...)
+		}},
+		{"DAG.Run", func(ctx context.Context, ws []Worker[int]) {
+			d := NewDAG[int]()
+			for i, w := range ws {
+				d.Add(strconv.Itoa(i), w)
+			}
+			d.Run(ctx)
+		}},
+		{"Map", func(ctx context.Context, ws []Worker[int]) {
+			Map(ctx, 0, ws, func(ctx context.Context, w Worker[int]) (int, error) { return w(ctx) })
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var seen []int
+			worker := func(ctx context.Context) (int, error) {
+				total, ok := TotalFromContext(ctx)
+				if !ok {
+					total = -1
+				}
+				mu.Lock()
+				seen = append(seen, total)
+				mu.Unlock()
+				return 1, nil
+			}
+
+			tc.run(context.Background(), []Worker[int]{worker, worker, worker})
+			time.Sleep(5 * time.Millisecond) // Let race losers report too
+			mu.Lock()
+			defer mu.Unlock()
+			if len(seen) == 0 {
+				t.Fatal("expected at least one worker to run")
+			}
+			for _, total := range seen {
+				if total != 3 {
+					t.Errorf("expected every worker to see a total of 3, got %v", seen)
+					break
+				}
+			}
+		})
+	}
+}
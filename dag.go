@@ -0,0 +1,166 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrCycle is returned by DAG.Run, before anything runs, when the dependencies form a cycle.
+var ErrCycle = errors.New("gocrc: dependency cycle")
+
+// ErrDependencyFailed is reported for a DAG node that did not run because one of its dependencies failed.
+var ErrDependencyFailed = errors.New("gocrc: dependency failed")
+
+// DAG runs workers that depend on each other's outputs. Every node starts as soon as all of its
+// dependencies have succeeded, so independent nodes run concurrently. A node reads the Results of
+// its direct dependencies with Upstream.
+type DAG[T any] struct {
+	nodes []dagNode[T]
+}
+
+type dagNode[T any] struct {
+	name   string
+	worker Worker[T]
+	deps   []string
+}
+
+// dagKey is the context key under which a DAG node finds the Results of its dependencies.
+type dagKey struct{}
+
+// NewDAG returns an empty DAG.
+func NewDAG[T any]() *DAG[T] {
+	return &DAG[T]{}
+}
+
+// Add adds the node name, running w once every node in deps has succeeded. Nodes may be added in
+// any order; names and dependencies are checked by Run.
+func (d *DAG[T]) Add(name string, w Worker[T], deps ...string) *DAG[T] {
+	d.nodes = append(d.nodes, dagNode[T]{name: name, worker: w, deps: deps})
+	return d
+}
+
+// Upstream returns the Results of the direct dependencies of the DAG node running under ctx,
+// keyed by node name. It returns nil outside a DAG node of the same type T.
+func Upstream[T any](ctx context.Context) map[string]Result[T] {
+	upstream, _ := ctx.Value(dagKey{}).(map[string]Result[T])
+	return upstream
+}
+
+// Run validates the graph and runs it, returning one Result per node in the order the nodes were
+// added, each carrying the node's name. Duplicate names, unknown dependencies and cycles (wrapping
+// ErrCycle) are reported before anything runs. A node whose dependency failed does not run and
+// reports ErrDependencyFailed; once the context is done, nodes not yet started report ctx.Err().
+func (d *DAG[T]) Run(ctx context.Context) ([]Result[T], error) {
+	byName, err := d.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result[T], len(d.nodes))
+	ctx = withTotal(ctx, len(d.nodes))
+	done := make([]chan struct{}, len(d.nodes))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	var completed atomic.Int64
+	wg.Add(len(d.nodes))
+	for i, node := range d.nodes {
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+			res := d.runNode(ctx, i, node, byName, results, done)
+			res.CompletionOrder = int(completed.Add(1) - 1)
+			results[i] = res
+		}()
+	}
+	wg.Wait()
+	return results, collectErrors(results)
+}
+
+// runNode waits for the dependencies of the node at index and then runs it. Reading the Results of
+// dependencies is safe once their done channels are closed.
+func (d *DAG[T]) runNode(ctx context.Context, index int, node dagNode[T], byName map[string]int, results []Result[T], done []chan struct{}) Result[T] {
+	upstream := make(map[string]Result[T], len(node.deps))
+	for _, dep := range node.deps {
+		j := byName[dep]
+		select {
+		case <-done[j]:
+		case <-ctx.Done():
+			return Result[T]{Err: ctx.Err(), Index: index, Name: node.name}
+		}
+		if err := results[j].Err; err != nil {
+			return Result[T]{Err: fmt.Errorf("%w: %q: %w", ErrDependencyFailed, dep, err), Index: index, Name: node.name}
+		}
+		upstream[dep] = results[j]
+	}
+	if err := ctx.Err(); err != nil {
+		return Result[T]{Err: err, Index: index, Name: node.name}
+	}
+
+	res := Result[T]{Index: index, Name: node.name, StartedAt: time.Now(), Started: true}
+	nodeCtx := context.WithValue(withWorker(ctx, index, node.name), dagKey{}, upstream)
+	res.Value, res.Err = call(nodeCtx, node.worker)
+	res.FinishedAt = time.Now()
+	return res
+}
+
+// validate checks names and dependencies and returns the index of every node by name.
+func (d *DAG[T]) validate() (map[string]int, error) {
+	byName := make(map[string]int, len(d.nodes))
+	for i, node := range d.nodes {
+		if _, dup := byName[node.name]; dup {
+			return nil, fmt.Errorf("gocrc: duplicate DAG node %q", node.name)
+		}
+		byName[node.name] = i
+	}
+	for _, node := range d.nodes {
+		for _, dep := range node.deps {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("gocrc: DAG node %q depends on unknown node %q", node.name, dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(d.nodes))
+	var path []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, d.nodes[i].name)
+			cycle := append(path[start:len(path):len(path)], d.nodes[i].name)
+			return fmt.Errorf("%w: %s", ErrCycle, strings.Join(cycle, " -> "))
+		}
+		state[i] = visiting
+		path = append(path, d.nodes[i].name)
+		for _, dep := range d.nodes[i].deps {
+			if err := visit(byName[dep]); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range d.nodes {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return byName, nil
+}
@@ -0,0 +1,107 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDAG(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("respects_dependencies", func(t *testing.T) {
+		var active, peak int32
+		leaf := func(v int) Worker[int] {
+			return func(ctx context.Context) (int, error) {
+				n := atomic.AddInt32(&active, 1)
+				defer atomic.AddInt32(&active, -1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				return v, nil
+			}
+		}
+		sum := func(ctx context.Context) (int, error) {
+			total := 0
+			for _, res := range Upstream[int](ctx) {
+				total += res.Value
+			}
+			return total, nil
+		}
+
+		results, err := NewDAG[int]().
+			Add("total", sum, "a", "b").
+			Add("a", leaf(1)).
+			Add("b", leaf(2)).
+			Run(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if results[0].Name != "total" || results[0].Value != 3 {
+			t.Errorf("expected total 3, got %+v", results[0])
+		}
+		if results[0].CompletionOrder != 2 {
+			t.Errorf("expected total to finish last, got order %d", results[0].CompletionOrder)
+		}
+		if p := atomic.LoadInt32(&peak); p != 2 {
+			t.Errorf("expected the independent nodes to overlap, peak was %d", p)
+		}
+	})
+
+	t.Run("dependency_failed", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		var ran int32
+		fail := func(ctx context.Context) (int, error) { return 0, errBoom }
+		never := func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&ran, 1)
+			return 1, nil
+		}
+
+		results, err := NewDAG[int]().Add("a", fail).Add("b", never, "a").Add("c", never, "b").Run(ctx)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		for _, res := range results[1:] {
+			if !errors.Is(res.Err, ErrDependencyFailed) || !errors.Is(res.Err, errBoom) {
+				t.Errorf("expected %q to report the failed dependency, got %v", res.Name, res.Err)
+			}
+		}
+		if r := atomic.LoadInt32(&ran); r != 0 {
+			t.Errorf("expected dependent nodes not to run, %d ran", r)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		var ran int32
+		w := func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&ran, 1)
+			return 0, nil
+		}
+
+		_, err := NewDAG[int]().Add("a", w, "c").Add("b", w, "a").Add("c", w, "b").Add("d", w).Run(ctx)
+		if !errors.Is(err, ErrCycle) || !strings.Contains(err.Error(), "a -> c -> b -> a") {
+			t.Errorf("expected the cycle a -> c -> b -> a, got %v", err)
+		}
+		if r := atomic.LoadInt32(&ran); r != 0 {
+			t.Errorf("expected nothing to run, %d ran", r)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		w := func(ctx context.Context) (int, error) { return 0, nil }
+
+		if _, err := NewDAG[int]().Add("a", w).Add("a", w).Run(ctx); err == nil {
+			t.Error("expected an error for a duplicate node")
+		}
+		if _, err := NewDAG[int]().Add("a", w, "missing").Run(ctx); err == nil {
+			t.Error("expected an error for an unknown dependency")
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package gocrc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Debouncer coalesces rapid triggers into a single run of its worker once d has passed without
+// another trigger. Each new trigger cancels the pending run and restarts the wait.
+type Debouncer[T any] struct {
+	d      time.Duration
+	worker Worker[T]
+
+	mu      sync.Mutex
+	gen     uint64
+	timer   *time.Timer
+	ctx     context.Context
+	waiters []chan Result[T]
+}
+
+// NewDebouncer returns a Debouncer that runs w after d of inactivity.
+func NewDebouncer[T any](d time.Duration, w Worker[T]) *Debouncer[T] {
+	return &Debouncer[T]{d: d, worker: w}
+}
+
+// Trigger schedules a run of the worker after d of inactivity and returns a channel that receives
+// the Result of the run this trigger was coalesced into. The run uses the context of the latest trigger.
+func (db *Debouncer[T]) Trigger(ctx context.Context) <-chan Result[T] {
+	out := make(chan Result[T], 1)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.waiters = append(db.waiters, out)
+	db.ctx = ctx
+	db.gen++
+	if db.timer != nil {
+		db.timer.Stop()
+	}
+	gen := db.gen
+	db.timer = time.AfterFunc(db.d, func() { db.fire(gen) })
+	return out
+}
+
+func (db *Debouncer[T]) fire(gen uint64) {
+	db.mu.Lock()
+	if gen != db.gen {
+		// Superseded by a later trigger whose timer will fire instead.
+		db.mu.Unlock()
+		return
+	}
+	ctx, waiters := db.ctx, db.waiters
+	db.ctx, db.waiters, db.timer = nil, nil, nil
+	db.mu.Unlock()
+
+	res := Result[T]{Index: IndexCancelled, StartedAt: time.Now(), Started: true}
+	res.Value, res.Err = call(ctx, db.worker)
+	res.FinishedAt = time.Now()
+	for _, ch := range waiters {
+		ch <- res
+	}
+}
+
+// Debounce wraps w so that concurrent rapid calls are coalesced into a single run after d of
+// inactivity. Every coalesced caller receives the result of that run, unless its own context ends first.
+func Debounce[T any](d time.Duration, w Worker[T]) Worker[T] {
+	db := NewDebouncer(d, w)
+	return func(ctx context.Context) (T, error) {
+		select {
+		case res := <-db.Trigger(ctx):
+			return res.Value, res.Err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
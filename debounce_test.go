@@ -0,0 +1,77 @@
+package gocrc
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebouncer(t *testing.T) {
+	t.Run("coalesces_burst", func(t *testing.T) {
+		var runs int32
+		db := NewDebouncer(30*time.Millisecond, func(ctx context.Context) (int32, error) {
+			return atomic.AddInt32(&runs, 1), nil
+		})
+
+		ctx := context.Background()
+		var chans []<-chan Result[int32]
+		for range 5 {
+			chans = append(chans, db.Trigger(ctx))
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		for _, ch := range chans {
+			res := <-ch
+			if res.Value != 1 || res.Err != nil || res.Index != IndexCancelled {
+				t.Errorf("expected every trigger to share run 1, got %v", res)
+			}
+			if !res.Started || res.StartedAt.IsZero() || res.FinishedAt.Before(res.StartedAt) {
+				t.Errorf("expected start and finish times, got %+v", res)
+			}
+		}
+		if r := atomic.LoadInt32(&runs); r != 1 {
+			t.Errorf("expected 1 run, got %d", r)
+		}
+	})
+
+	t.Run("quiet_period_allows_new_run", func(t *testing.T) {
+		var runs int32
+		db := NewDebouncer(10*time.Millisecond, func(ctx context.Context) (int32, error) {
+			return atomic.AddInt32(&runs, 1), nil
+		})
+
+		ctx := context.Background()
+		first := <-db.Trigger(ctx)
+		second := <-db.Trigger(ctx)
+		if first.Value != 1 || second.Value != 2 {
+			t.Errorf("expected two separate runs, got %d and %d", first.Value, second.Value)
+		}
+	})
+}
+
+func TestDebounce(t *testing.T) {
+	var runs int32
+	w := Debounce(30*time.Millisecond, func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&runs, 1)
+		return "reindexed", nil
+	})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := w(ctx); v != "reindexed" || err != nil {
+				t.Errorf("unexpected result: %v, %v", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if r := atomic.LoadInt32(&runs); r != 1 {
+		t.Errorf("expected 1 run, got %d", r)
+	}
+}
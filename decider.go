@@ -0,0 +1,117 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// ErrStopped is the cancellation cause seen by the workers that a WithDecider function cancelled.
+var ErrStopped = errors.New("gocrc: stopped by decider")
+
+// WithDecider hands every Result to fn as its worker completes, after the observers such as
+// WithProgress. Once fn reports true, NoRaceWith cancels the remaining workers, with context.Cause
+// reporting ErrStopped, and returns early: workers that had not completed carry ErrUnfinished, and
+// the MultiError lists only the workers that actually failed. Race, RaceSuccess and NoRaceFailFast
+// are the deciders "stop at the first Result", "at the first success" and "at the first error".
+// Calls to fn are serialized. As with NoRaceFailFast, a NoRaceWith call with a decider stops waiting
+// once ctx is done, with an error wrapping ErrCancelled and ctx.Err(). WithDecider has no effect with
+// WithScheduler or in RaceWith.
+func WithDecider[T any](fn func(Result[T]) (stop bool)) Option[T] {
+	return func(o *options[T]) {
+		o.decider = fn
+	}
+}
+
+// decide runs the workers through exec and hands their Results to stop as they arrive, on the calling
+// goroutine. The first Result for which stop reports true is returned with ok set, after the other
+// workers have been cancelled with cause(res); their Results are discarded. If ctx is done first,
+// decide returns ctx.Err() without waiting. Otherwise it returns once every Result was handed to stop.
+func decide[T any](ctx context.Context, workers []Worker[T], exec func(context.Context, int, Worker[T]) Result[T], stop func(Result[T]) bool, cause func(Result[T]) error) (res Result[T], ok bool, err error) {
+	decideCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	resultCh := spawnWith(decideCtx, workers, exec)
+
+	for received := 1; received <= len(workers); received++ {
+		select {
+		case res := <-resultCh:
+			if stop(res) {
+				cancel(cause(res))
+				go discard(resultCh, len(workers)-received)
+				return res, true, nil
+			}
+		case <-ctx.Done():
+			return Result[T]{}, false, ctx.Err()
+		}
+	}
+	return Result[T]{}, false, nil
+}
+
+// runDecided is NoRaceWith for a call with WithDecider.
+func runDecided[T any](ctx context.Context, o *options[T], workers []Worker[T]) ([]Result[T], error) {
+	results := o.resultSlice(len(workers))
+	done := make([]bool, len(workers))
+	started := make([]atomic.Bool, len(workers))
+	o.onStart = func(index int) { started[index].Store(true) }
+	var failures []Result[T]
+	completed := 0
+	_, _, err := decide(ctx, workers, o.exec, func(res Result[T]) bool {
+		results[res.Index] = res
+		done[res.Index] = true
+		if failed(res) {
+			failures = append(failures, res)
+		}
+		completed++
+		o.observe(res, completed, len(workers))
+		return o.decider(res)
+	}, func(Result[T]) error { return ErrStopped })
+
+	markUnfinished(results, done, started)
+	for i := range results {
+		if !done[i] {
+			results[i].Name = o.name(i)
+		}
+	}
+	if err != nil {
+		return results, fmt.Errorf("%w: %w", ErrCancelled, err)
+	}
+	if len(failures) > 0 {
+		slices.SortFunc(failures, func(a, b Result[T]) int { return a.Index - b.Index })
+		return results, &MultiError[T]{Results: failures}
+	}
+	return results, nil
+}
+
+// markUnfinished sets the Results of the workers that are not done to ErrUnfinished, keeping whether
+// they had started so that a worker abandoned mid-run is not mistaken for one never scheduled.
+func markUnfinished[T any](results []Result[T], done []bool, started []atomic.Bool) {
+	for i := range results {
+		if !done[i] {
+			results[i] = Result[T]{Err: ErrUnfinished, Index: i, Started: started[i].Load()}
+		}
+	}
+}
+
+// trackStart wraps exec, which must start the worker body right away as invoke does, so that
+// started[index] is set once the worker at index starts.
+func trackStart[T any](started []atomic.Bool, exec func(context.Context, int, Worker[T]) Result[T]) func(context.Context, int, Worker[T]) Result[T] {
+	return func(ctx context.Context, index int, worker Worker[T]) Result[T] {
+		started[index].Store(true)
+		return exec(ctx, index, worker)
+	}
+}
+
+// always is the decider of Race: the first Result wins.
+func always[T any](Result[T]) bool { return true }
+
+// succeeded is the decider of RaceSuccess.
+func succeeded[T any](res Result[T]) bool { return res.Err == nil }
+
+// failed is the decider of NoRaceFailFast.
+func failed[T any](res Result[T]) bool { return res.Err != nil }
+
+// raceLost is the cancellation cause of the losers of a race.
+func raceLost[T any](Result[T]) error { return ErrRaceLost }
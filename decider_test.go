@@ -0,0 +1,84 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithDecider(t *testing.T) {
+	t.Run("stop_on_threshold", func(t *testing.T) {
+		cause := make(chan error, 1)
+		worker := func(n int) Worker[int] {
+			return func(ctx context.Context) (int, error) {
+				if n < 0 {
+					<-ctx.Done()
+					cause <- context.Cause(ctx)
+					return 0, ctx.Err()
+				}
+				time.Sleep(time.Duration(n) * time.Millisecond)
+				return n, nil
+			}
+		}
+
+		sum := 0
+		opts := []Option[int]{
+			WithNames[int]("a", "b", "c", "d"),
+			WithDecider(func(res Result[int]) bool {
+				sum += res.Value
+				return sum >= 30
+			}),
+		}
+		results, err := NoRaceWith(context.Background(), opts, worker(10), worker(20), worker(-1), worker(50))
+
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if results[0].Value != 10 || results[1].Value != 20 {
+			t.Errorf("expected the first two results, got %v", results)
+		}
+		for i, name := range []string{2: "c", 3: "d"} {
+			if i >= 2 && (results[i].Err != ErrUnfinished || results[i].Name != name || !results[i].Started) {
+				t.Errorf("expected worker %d to be unfinished and named, got %+v", i, results[i])
+			}
+		}
+		if err := <-cause; !errors.Is(err, ErrStopped) {
+			t.Errorf("expected the cancelled worker to see ErrStopped, got %v", err)
+		}
+	})
+
+	t.Run("failures_listed", func(t *testing.T) {
+		boom := errors.New("boom")
+		opts := []Option[int]{WithDecider(func(res Result[int]) bool { return false })}
+		results, err := NoRaceWith(context.Background(), opts,
+			func(ctx context.Context) (int, error) { return 1, nil },
+			func(ctx context.Context) (int, error) { return 0, boom },
+		)
+
+		merr, ok := err.(*MultiError[int])
+		if !ok || len(merr.Results) != 1 || merr.Results[0].Index != 1 {
+			t.Errorf("expected worker 1 to fail, got %v", err)
+		}
+		if results[0].Value != 1 {
+			t.Errorf("expected every result when the decider never stops, got %v", results)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		opts := []Option[int]{WithDecider(func(res Result[int]) bool { return false })}
+		results, err := NoRaceWith(ctx, opts, func(ctx context.Context) (int, error) {
+			time.Sleep(time.Second)
+			return 1, nil
+		})
+
+		if !errors.Is(err, ErrCancelled) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected ErrCancelled wrapping the deadline, got %v", err)
+		}
+		if results[0].Err != ErrUnfinished {
+			t.Errorf("expected the worker to be unfinished, got %v", results[0].Err)
+		}
+	})
+}
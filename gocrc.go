@@ -2,9 +2,14 @@ package gocrc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime/debug"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Result represents the outcome of a worker's execution.
@@ -12,8 +17,27 @@ type Result[T any] struct {
 	Value T
 	Err   error
 	Index int
+	// CompletionOrder is the 0-based rank in which the worker finished relative to its siblings.
+	CompletionOrder int
+	// Name is the worker's label, if it was given one. See NamedWorker and WithNames.
+	Name string
+	// StartedAt and FinishedAt bracket the worker body, using the monotonic clock reading of
+	// time.Now. StartedAt is zero for a worker that never started.
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Partial reports that the worker returned during its grace period after a soft timeout,
+	// so Value may hold incomplete data. See WithSoftTimeout.
+	Partial bool
+	// Started reports that the worker body was run. It tells a worker that was never scheduled, e.g.
+	// because of a limit or cancellation, apart from one that returned a zero Value and a nil error.
+	Started bool
 }
 
+// IndexCancelled is the Index of a Result that cannot be attributed to any worker, such as the
+// Result of a race that ended because the parent context was done, of a call with no workers, or
+// of a run outside any batch, e.g. by SingleFlight or Debouncer.
+const IndexCancelled = -1
+
 // Worker is a function that performs a task and returns a value of type T.
 type Worker[T any] func(ctx context.Context) (T, error)
 
@@ -26,59 +50,469 @@ func (m *MultiError[T]) Error() string {
 	var sb strings.Builder
 	sb.WriteString("multiple errors occurred:")
 	for _, res := range m.Results {
-		if res.Err != nil {
+		if res.Err == nil {
+			continue
+		}
+		if _, ok := res.Err.(*workerError); ok {
+			sb.WriteString(fmt.Sprintf("\n - %v", res.Err))
+		} else if res.Name != "" {
+			sb.WriteString(fmt.Sprintf("\n - Worker [%d] %q: %v", res.Index, res.Name, res.Err))
+		} else {
 			sb.WriteString(fmt.Sprintf("\n - Worker [%d]: %v", res.Index, res.Err))
 		}
 	}
 	return sb.String()
 }
 
+// Compact formats the errors on a single line, e.g. `2 errors: [1] boom; [3] timeout`, for log
+// sinks that mangle the newlines of Error.
+func (m *MultiError[T]) Compact() string {
+	var sb strings.Builder
+	n := 0
+	for _, res := range m.Results {
+		if res.Err == nil {
+			continue
+		}
+		if n > 0 {
+			sb.WriteString("; ")
+		}
+		n++
+		// Nested multi-line errors, such as another MultiError, are folded onto the line too.
+		msg := strings.ReplaceAll(res.Err.Error(), "\n", " ")
+		if _, ok := res.Err.(*workerError); ok {
+			sb.WriteString(msg)
+		} else if res.Name != "" {
+			sb.WriteString(fmt.Sprintf("[%d] %q: %s", res.Index, res.Name, msg))
+		} else {
+			sb.WriteString(fmt.Sprintf("[%d] %s", res.Index, msg))
+		}
+	}
+	if n == 1 {
+		return "1 error: " + sb.String()
+	}
+	return fmt.Sprintf("%d errors: %s", n, sb.String())
+}
+
+// Unwrap returns the underlying worker errors so that errors.Is and errors.As can match any of them.
+func (m *MultiError[T]) Unwrap() []error {
+	return m.Errors()
+}
+
+// Errors returns the underlying worker errors, in the order of Results.
+func (m *MultiError[T]) Errors() []error {
+	return AllErrors(m.Results)
+}
+
+// Indices returns the indices of the failed workers, in the order of Results.
+func (m *MultiError[T]) Indices() []int {
+	var indices []int
+	for _, res := range m.Results {
+		if res.Err != nil {
+			indices = append(indices, res.Index)
+		}
+	}
+	return indices
+}
+
+// PanicError is stored in a worker's Result when the worker panics instead of returning.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("worker panicked: %v", p.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (p *PanicError) Unwrap() error {
+	if err, ok := p.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// call invokes the worker, converting a panic into a *PanicError.
+func call[T any](ctx context.Context, worker Worker[T]) (val T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			val, err = zero, &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return worker(ctx)
+}
+
+// invoke runs the worker at index and returns its Result, timestamps included.
+func invoke[T any](ctx context.Context, index int, worker Worker[T]) Result[T] {
+	res := Result[T]{Index: index, StartedAt: time.Now(), Started: true}
+	res.Value, res.Err = call(withWorker(ctx, index, ""), worker)
+	res.FinishedAt = time.Now()
+	return res
+}
+
+// spawn starts every worker in its own goroutine and returns a channel receiving their Results.
+// The channel is buffered to the number of workers so that no worker blocks, even if the caller
+// stops receiving early.
+func spawn[T any](ctx context.Context, workers []Worker[T]) <-chan Result[T] {
+	return spawnWith(ctx, workers, invoke[T])
+}
+
+// spawnWith is like spawn but runs each worker through exec, e.g. options.exec, instead of invoke.
+func spawnWith[T any](ctx context.Context, workers []Worker[T], exec func(context.Context, int, Worker[T]) Result[T]) <-chan Result[T] {
+	resultCh := make(chan Result[T], len(workers))
+	ctx = withTotal(ctx, len(workers))
+	var completed atomic.Int64
+	for i := range workers {
+		index := i
+		worker := workers[i]
+		go func() {
+			res := exec(ctx, index, worker)
+			res.CompletionOrder = int(completed.Add(1) - 1)
+			resultCh <- res
+		}()
+	}
+	return resultCh
+}
+
+// discard receives and drops n Results from ch, so that their values can be freed right away
+// instead of waiting in the channel buffer.
+func discard[T any](ch <-chan Result[T], n int) {
+	for range n {
+		<-ch
+	}
+}
+
+// ErrRaceLost is the cancellation cause, as reported by context.Cause, seen by the workers of a race
+// that were cancelled because another worker won it.
+var ErrRaceLost = errors.New("gocrc: another worker won the race")
+
+// ErrSiblingFailed is the cancellation cause, wrapping the failing worker's error, seen by the workers
+// cancelled by fail-fast calls such as NoRaceFailFast because one of their siblings failed.
+var ErrSiblingFailed = errors.New("gocrc: sibling worker failed")
+
+func siblingFailed[T any](res Result[T]) error {
+	return fmt.Errorf("%w: worker [%d]: %w", ErrSiblingFailed, res.Index, res.Err)
+}
+
 // Race runs multiple workers concurrently. The first worker to complete (successfully or with error)
-// will cause all other workers to be cancelled immediately.
-// Returns the result of the first worker to complete.
+// will cause all other workers to be cancelled immediately; context.Cause of their context reports ErrRaceLost.
+// Returns the result of the first worker to complete. A panicking worker completes with a *PanicError.
+// If ctx is done before any worker completes, the Result's Index is IndexCancelled.
 func Race[T any](ctx context.Context, workers ...Worker[T]) (Result[T], error) {
+	results, err := Run(ctx, Config[T]{Workers: workers, Mode: RunRace})
+	return results[0], err
+}
+
+// RaceBG is Race under context.Background(), for callers without a context. The losers are still
+// cancelled through the context the workers receive.
+func RaceBG[T any](workers ...Worker[T]) (Result[T], error) {
+	return Race(context.Background(), workers...)
+}
+
+// RaceWith is like Race but applies the given options. Options that shape how NoRaceWith collects
+// its Results have no effect here: the completion observers (WithProgress, WithOnResult, WithTiming),
+// WithDecider (a race always stops at the first Result), WithScheduler, WithResultBuffer, and
+// WithReturnOnCancel (a race always returns as soon as ctx is done).
+func RaceWith[T any](ctx context.Context, opts []Option[T], workers ...Worker[T]) (Result[T], error) {
 	if len(workers) == 0 {
-		return Result[T]{}, nil
+		return Result[T]{Index: IndexCancelled}, nil
 	}
 
-	raceCtx, cancel := context.WithCancel(ctx)
+	o := newOptions(opts)
+	ctx, release := o.scope(ctx)
+	defer release()
+
+	res, ok, err := decide(ctx, workers, o.exec, always[T], raceLost[T])
+	if !ok {
+		return Result[T]{Index: IndexCancelled, Err: err}, err
+	}
+	return res, res.Err
+}
+
+// RaceTimed is like Race but also returns how long the winner took, measured from the call to RaceTimed
+// up to the winner's FinishedAt, so that scheduling overhead is included. If ctx is done before any
+// worker completes, the duration is the time until RaceTimed gave up.
+func RaceTimed[T any](ctx context.Context, workers ...Worker[T]) (Result[T], time.Duration, error) {
+	start := time.Now()
+	res, err := Race(ctx, workers...)
+	if res.FinishedAt.IsZero() {
+		return res, time.Since(start), err
+	}
+	return res, res.FinishedAt.Sub(start), err
+}
+
+// ErrRaceTimeout is returned by RaceOrTimeout when no worker finished within the timeout.
+// It wraps context.DeadlineExceeded.
+var ErrRaceTimeout = fmt.Errorf("gocrc: race timed out: %w", context.DeadlineExceeded)
+
+// RaceOrTimeout is like Race under an overall timeout. If no worker finishes in time, all of them are
+// cancelled and ErrRaceTimeout is returned with an IndexCancelled Result, also when a worker gave
+// up with the context error first. The parent context being done is reported as by Race.
+func RaceOrTimeout[T any](ctx context.Context, timeout time.Duration, workers ...Worker[T]) (Result[T], error) {
+	timeoutCtx, cancel := context.WithTimeoutCause(ctx, timeout, ErrRaceTimeout)
 	defer cancel()
 
+	res, err := Race(timeoutCtx, workers...)
+	if err != nil && ctx.Err() == nil && context.Cause(timeoutCtx) == ErrRaceTimeout {
+		return Result[T]{Index: IndexCancelled, Err: ErrRaceTimeout}, ErrRaceTimeout
+	}
+	return res, err
+}
+
+// RaceResult is the outcome of RaceDetailed: the winning Result plus what happened to the other workers.
+type RaceResult[T any] struct {
+	Result[T]
+	// Running lists, in index order, the workers that were still running when the race ended,
+	// including those that only returned after it ended.
+	Running []int
+	// Cancelled is the number of workers in Running, i.e. those cancelled while still running.
+	Cancelled int
+}
+
+// RaceDetailed is like Race but also reports which workers were still running when the race ended.
+// This helps to understand why a race ended on ctx cancellation rather than producing a winner.
+func RaceDetailed[T any](ctx context.Context, workers ...Worker[T]) (RaceResult[T], error) {
+	if len(workers) == 0 {
+		return RaceResult[T]{Result: Result[T]{Index: IndexCancelled}}, nil
+	}
+
+	raceCtx, cancel := context.WithCancelCause(withTotal(ctx, len(workers)))
+	defer cancel(nil)
+
 	resultCh := make(chan Result[T], 1)
+	var mu sync.Mutex
+	finished := make([]bool, len(workers))
 
 	for i := range workers {
 		index := i
 		worker := workers[i]
 		go func() {
-			val, err := worker(raceCtx)
-			res := Result[T]{Value: val, Err: err, Index: index}
+			res := invoke(raceCtx, index, worker)
+
+			// A worker returning after the race context ended counts as cancelled.
+			mu.Lock()
+			finished[index] = raceCtx.Err() == nil
+			mu.Unlock()
+
 			select {
 			case resultCh <- res:
-				cancel() // Signal others to stop
+				cancel(ErrRaceLost)
 			case <-raceCtx.Done():
-				// Another worker already won
 			}
 		}()
 	}
 
+	var res Result[T]
+	select {
+	case res = <-resultCh:
+	case <-ctx.Done():
+		res = Result[T]{Index: IndexCancelled, Err: ctx.Err()}
+	}
+
+	mu.Lock()
+	var running []int
+	for i, done := range finished {
+		if !done && i != res.Index {
+			running = append(running, i)
+		}
+	}
+	mu.Unlock()
+
+	return RaceResult[T]{Result: res, Running: running, Cancelled: len(running)}, res.Err
+}
+
+// RaceVerbose is like Race but, after cancelling the losers, waits for them to return and reports the
+// final Result of every worker, index-aligned with the input (the winner's slot holds the winner).
+// Most losers will carry a context error. It is meant for diagnosing slow losers; note that it only
+// returns once every worker has returned.
+func RaceVerbose[T any](ctx context.Context, workers ...Worker[T]) (Result[T], []Result[T], error) {
+	if len(workers) == 0 {
+		return Result[T]{Index: IndexCancelled}, nil, nil
+	}
+
+	raceCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	resultCh := spawn(raceCtx, workers)
+	all := make([]Result[T], len(workers))
+	winner := Result[T]{Index: IndexCancelled}
+
 	select {
 	case res := <-resultCh:
-		return res, res.Err
+		cancel(ErrRaceLost)
+		winner = res
+		all[res.Index] = res
 	case <-ctx.Done():
-		return Result[T]{Index: -1, Err: ctx.Err()}, ctx.Err()
+		winner.Err = ctx.Err()
+	}
+
+	remaining := len(workers)
+	if winner.Index >= 0 {
+		remaining--
+	}
+	for range remaining {
+		res := <-resultCh
+		all[res.Index] = res
 	}
+	return winner, all, winner.Err
+}
+
+// RaceSuccess runs multiple workers concurrently and returns the first one to complete successfully,
+// cancelling all others. Errors from workers that fail early are ignored as long as another worker
+// may still succeed. If every worker fails, a MultiError holding all failures (in index order) is returned.
+// Values of losing workers are never retained, which matters when they are large buffers: the failures
+// in the MultiError have a zero Value, and Results of workers still running when the winner is found
+// are discarded as soon as they arrive.
+func RaceSuccess[T any](ctx context.Context, workers ...Worker[T]) (Result[T], error) {
+	if len(workers) == 0 {
+		return Result[T]{Index: IndexCancelled}, nil
+	}
+
+	var failures []Result[T]
+	res, ok, err := decide(ctx, workers, invoke[T], func(res Result[T]) bool {
+		if succeeded(res) {
+			return true
+		}
+		var zero T
+		res.Value = zero
+		failures = append(failures, res)
+		return false
+	}, raceLost[T])
+	if ok {
+		return res, nil
+	}
+	if err != nil {
+		return Result[T]{Index: IndexCancelled, Err: err}, err
+	}
+
+	slices.SortFunc(failures, func(a, b Result[T]) int { return a.Index - b.Index })
+	merr := &MultiError[T]{Results: failures}
+	return Result[T]{Index: IndexCancelled, Err: merr}, merr
+}
+
+// First runs multiple workers concurrently and returns as soon as n of them have completed successfully,
+// cancelling the rest. The returned results are in completion order.
+// If it becomes impossible for n workers to succeed, the successes so far are returned together with a
+// MultiError describing the failures.
+func First[T any](ctx context.Context, n int, workers ...Worker[T]) ([]Result[T], error) {
+	if n <= 0 || len(workers) == 0 {
+		return nil, nil
+	}
+	if n > len(workers) {
+		return nil, fmt.Errorf("gocrc: cannot collect %d results from %d workers", n, len(workers))
+	}
+
+	firstCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	resultCh := spawn(firstCtx, workers)
+
+	wins := make([]Result[T], 0, n)
+	var failures []Result[T]
+	for range workers {
+		select {
+		case res := <-resultCh:
+			if res.Err == nil {
+				wins = append(wins, res)
+				if len(wins) == n {
+					cancel(ErrRaceLost)
+					return wins, nil
+				}
+				continue
+			}
+			failures = append(failures, res)
+			if len(workers)-len(failures) < n {
+				slices.SortFunc(failures, func(a, b Result[T]) int { return a.Index - b.Index })
+				return wins, &MultiError[T]{Results: failures}
+			}
+		case <-ctx.Done():
+			return wins, ctx.Err()
+		}
+	}
+	return wins, nil
 }
 
 // NoRace runs multiple workers concurrently and waits for all of them to complete.
 // Returns a slice of all results (in order) and a MultiError if any workers failed.
+// A panicking worker does not affect the others; its Result holds a *PanicError.
 func NoRace[T any](ctx context.Context, workers ...Worker[T]) ([]Result[T], error) {
+	return Run(ctx, Config[T]{Workers: workers})
+}
+
+// NoRaceBG is NoRace under context.Background(), for callers without a context.
+func NoRaceBG[T any](workers ...Worker[T]) ([]Result[T], error) {
+	return NoRace(context.Background(), workers...)
+}
+
+// NamedWorker pairs a worker with a label that is reported in its Result and in error messages.
+type NamedWorker[T any] struct {
+	Name   string
+	Worker Worker[T]
+}
+
+// NoRaceNamed is like NoRace for named workers: each Result, and each MultiError entry, carries
+// the worker's Name.
+func NoRaceNamed[T any](ctx context.Context, workers ...NamedWorker[T]) ([]Result[T], error) {
+	names := make([]string, len(workers))
+	plain := make([]Worker[T], len(workers))
+	for i, nw := range workers {
+		names[i] = nw.Name
+		plain[i] = nw.Worker
+	}
+	return NoRaceWith(ctx, []Option[T]{WithNames[T](names...)}, plain...)
+}
+
+// NoRaceSorted runs multiple workers concurrently like NoRace and returns only the successful results,
+// ordered by less on their values, e.g. best-scored candidate first. Results with equal values keep
+// index order, and less is never called on the values of failed workers. Failures are reported in a
+// MultiError, in index order, as by NoRace.
+func NoRaceSorted[T any](ctx context.Context, less func(a, b T) bool, workers ...Worker[T]) ([]Result[T], error) {
+	results, err := NoRace(ctx, workers...)
+	succeeded := slices.DeleteFunc(results, func(r Result[T]) bool { return r.Err != nil })
+	slices.SortStableFunc(succeeded, func(a, b Result[T]) int {
+		switch {
+		case less(a.Value, b.Value):
+			return -1
+		case less(b.Value, a.Value):
+			return 1
+		}
+		return 0
+	})
+	return succeeded, err
+}
+
+// NoRaceWith is like NoRace but applies the given options.
+func NoRaceWith[T any](ctx context.Context, opts []Option[T], workers ...Worker[T]) ([]Result[T], error) {
 	if len(workers) == 0 {
 		return nil, nil
 	}
 
-	results := make([]Result[T], len(workers))
+	o := newOptions(opts)
+	ctx, release := o.scope(ctx)
+	defer release()
+	ctx = withTotal(ctx, len(workers))
+
+	if o.scheduler != nil {
+		return runScheduled(ctx, o, workers)
+	}
+	if o.decider != nil {
+		return runDecided(ctx, o, workers)
+	}
+
+	results := o.resultSlice(len(workers))
+	done := make([]bool, len(workers))
+	var started []atomic.Bool
+	if o.returnOnCancel {
+		started = make([]atomic.Bool, len(workers))
+		o.onStart = func(index int) { started[index].Store(true) }
+	}
 	var wg sync.WaitGroup
 	var hasError bool
+	var completed int
+	var abandoned bool
 	var mu sync.Mutex
 
 	wg.Add(len(workers))
@@ -87,31 +521,206 @@ func NoRace[T any](ctx context.Context, workers ...Worker[T]) ([]Result[T], erro
 		worker := workers[i]
 		go func() {
 			defer wg.Done()
-			val, err := worker(ctx)
+			res := o.exec(ctx, index, worker)
 
 			mu.Lock()
-			results[index] = Result[T]{
-				Value: val,
-				Err:   err,
-				Index: index,
+			defer mu.Unlock()
+			if abandoned {
+				return
 			}
-			if err != nil {
+			res.CompletionOrder = completed
+			results[index] = res
+			done[index] = true
+			if res.Err != nil {
 				hasError = true
 			}
-			mu.Unlock()
+			completed++
+			o.observe(results[index], completed, len(workers))
+		}()
+	}
+
+	if o.returnOnCancel {
+		allDone := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(allDone)
 		}()
+
+		select {
+		case <-allDone:
+		case <-ctx.Done():
+			mu.Lock()
+			defer mu.Unlock()
+			if completed < len(workers) {
+				abandoned = true
+				partial := make([]Result[T], len(results))
+				for i := range results {
+					if done[i] {
+						partial[i] = results[i]
+					} else {
+						partial[i] = Result[T]{Err: ErrUnfinished, Index: i, Name: o.name(i), Started: started[i].Load()}
+					}
+				}
+				return partial, fmt.Errorf("%w: %w", ErrCancelled, ctx.Err())
+			}
+		}
 	}
 
 	wg.Wait()
 
 	if hasError {
-		var errResults []Result[T]
-		for _, r := range results {
-			if r.Err != nil {
-				errResults = append(errResults, r)
+		return results, collectErrors(results)
+	}
+	return results, nil
+}
+
+// ErrUnfinished marks the Result of a worker that had not completed when its caller stopped waiting.
+var ErrUnfinished = errors.New("gocrc: worker did not finish")
+
+// ErrCancelled is returned, wrapping the context error, when a call stops waiting for its workers
+// because the context was cancelled. See WithReturnOnCancel.
+var ErrCancelled = errors.New("gocrc: cancelled before all workers finished")
+
+// NoRaceFailFast runs multiple workers concurrently like NoRace, but the moment any worker fails
+// it cancels the others, with a cause wrapping ErrSiblingFailed and the failure, and stops waiting.
+// It returns the results completed so far (in order) together with the triggering error.
+// Workers that had not completed carry ErrUnfinished in their Result. If ctx is done first, it
+// stops waiting too and the error wraps ErrCancelled and ctx.Err(), as with WithReturnOnCancel.
+func NoRaceFailFast[T any](ctx context.Context, workers ...Worker[T]) ([]Result[T], error) {
+	if len(workers) == 0 {
+		return nil, nil
+	}
+
+	results := make([]Result[T], len(workers))
+	done := make([]bool, len(workers))
+	started := make([]atomic.Bool, len(workers))
+	res, ok, err := decide(ctx, workers, trackStart(started, invoke[T]), func(res Result[T]) bool {
+		results[res.Index] = res
+		done[res.Index] = true
+		return failed(res)
+	}, siblingFailed[T])
+
+	markUnfinished(results, done, started)
+	if ok {
+		return results, res.Err
+	}
+	if err != nil {
+		return results, fmt.Errorf("%w: %w", ErrCancelled, err)
+	}
+	return results, nil
+}
+
+// NoRaceMaxErrors runs multiple workers concurrently like NoRace, but once maxErrors workers have
+// failed it cancels the others and stops waiting. Workers that had not completed carry ErrUnfinished
+// in their Result, and the returned *MultiError lists only the workers that actually failed, in index
+// order. A maxErrors below 1 behaves like 1, i.e. like NoRaceFailFast, including when ctx is done.
+func NoRaceMaxErrors[T any](ctx context.Context, maxErrors int, workers ...Worker[T]) ([]Result[T], error) {
+	if len(workers) == 0 {
+		return nil, nil
+	}
+	maxErrors = max(maxErrors, 1)
+
+	results := make([]Result[T], len(workers))
+	done := make([]bool, len(workers))
+	started := make([]atomic.Bool, len(workers))
+	var failures []Result[T]
+	// Results are counted by the decider, on a single goroutine, so the threshold needs no locking.
+	_, ok, err := decide(ctx, workers, trackStart(started, invoke[T]), func(res Result[T]) bool {
+		results[res.Index] = res
+		done[res.Index] = true
+		if failed(res) {
+			failures = append(failures, res)
+		}
+		return len(failures) >= maxErrors
+	}, siblingFailed[T])
+
+	markUnfinished(results, done, started)
+	if ok {
+		slices.SortFunc(failures, func(a, b Result[T]) int { return a.Index - b.Index })
+		return results, &MultiError[T]{Results: failures}
+	}
+	if err != nil {
+		return results, fmt.Errorf("%w: %w", ErrCancelled, err)
+	}
+	return results, collectErrors(results)
+}
+
+// ErrDeadlineCutoff marks the Result of a worker that was cut off by the batch deadline of
+// NoRaceDeadline, as opposed to one that finished with its own error. It wraps context.DeadlineExceeded.
+var ErrDeadlineCutoff = fmt.Errorf("gocrc: worker cut off by deadline: %w", context.DeadlineExceeded)
+
+// NoRaceDeadline runs multiple workers concurrently like NoRace, but only until deadline.
+// Workers that finished in time keep their real results; the others are cancelled, no longer awaited
+// and carry ErrDeadlineCutoff. A MultiError is returned if any worker failed or was cut off.
+func NoRaceDeadline[T any](ctx context.Context, deadline time.Time, workers ...Worker[T]) ([]Result[T], error) {
+	if len(workers) == 0 {
+		return nil, nil
+	}
+
+	deadlineCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	results := make([]Result[T], len(workers))
+	done := make([]bool, len(workers))
+	started := make([]atomic.Bool, len(workers))
+	resultCh := spawnWith(deadlineCtx, workers, trackStart(started, invoke[T]))
+	received := 0
+
+	store := func(res Result[T]) {
+		results[res.Index] = res
+		done[res.Index] = true
+		received++
+	}
+
+wait:
+	for received < len(workers) {
+		select {
+		case res := <-resultCh:
+			store(res)
+		case <-deadlineCtx.Done():
+			// Keep whatever completed right at the deadline.
+			for {
+				select {
+				case res := <-resultCh:
+					store(res)
+					continue
+				default:
+				}
+				break wait
 			}
 		}
-		return results, &MultiError[T]{Results: errResults}
 	}
-	return results, nil
+
+	cutoff := ErrDeadlineCutoff
+	if ctx.Err() != nil {
+		cutoff = ctx.Err()
+	}
+	for i := range results {
+		if !done[i] {
+			results[i] = Result[T]{Err: cutoff, Index: i, Started: started[i].Load()}
+		}
+	}
+	return results, collectErrors(results)
+}
+
+// Sequential runs the workers one at a time in index order, with the same contract as NoRace:
+// all Results in order plus a MultiError if any worker failed. It is meant for debugging and for
+// deterministic comparisons. Once the context is done, the remaining workers are not started and
+// their Results carry ctx.Err().
+func Sequential[T any](ctx context.Context, workers ...Worker[T]) ([]Result[T], error) {
+	if len(workers) == 0 {
+		return nil, nil
+	}
+
+	results := make([]Result[T], len(workers))
+	ctx = withTotal(ctx, len(workers))
+	for i, worker := range workers {
+		if err := ctx.Err(); err != nil {
+			results[i] = Result[T]{Err: err, Index: i, CompletionOrder: i}
+			continue
+		}
+		results[i] = invoke(ctx, i, worker)
+		results[i].CompletionOrder = i
+	}
+	return results, collectErrors(results)
 }
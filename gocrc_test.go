@@ -3,6 +3,8 @@ package gocrc
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -63,6 +65,230 @@ func TestRace(t *testing.T) {
 			t.Errorf("expected index 0, got %d", res.Index)
 		}
 	})
+
+	t.Run("parent_cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		w := func(ctx context.Context) (int, error) {
+			time.Sleep(200 * time.Millisecond)
+			return 1, nil
+		}
+
+		res, err := Race(ctx, w)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+		if res.Index != IndexCancelled {
+			t.Errorf("expected IndexCancelled, got %d", res.Index)
+		}
+	})
+
+	t.Run("no_workers", func(t *testing.T) {
+		res, err := Race[int](context.Background())
+		if err != nil || res.Index != IndexCancelled {
+			t.Errorf("expected IndexCancelled and nil error, got %d, %v", res.Index, err)
+		}
+	})
+
+	t.Run("panic_is_recovered", func(t *testing.T) {
+		ctx := context.Background()
+		w1 := func(ctx context.Context) (int, error) { panic(errors.New("boom")) }
+
+		_, err := Race(ctx, w1)
+		var perr *PanicError
+		if !errors.As(err, &perr) {
+			t.Fatalf("expected *PanicError, got %T", err)
+		}
+		if err.Error() != "worker panicked: boom" {
+			t.Errorf("unexpected message: %v", err)
+		}
+	})
+}
+
+func TestRaceDetailed(t *testing.T) {
+	slow := func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+
+	t.Run("winner_reports_cancelled", func(t *testing.T) {
+		ctx := context.Background()
+		fast := func(ctx context.Context) (int, error) { return 1, nil }
+
+		res, err := RaceDetailed(ctx, slow, fast, slow)
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if res.Index != 1 || res.Value != 1 {
+			t.Errorf("expected worker 1 to win, got %v", res.Result)
+		}
+		if res.Cancelled != 2 || len(res.Running) != 2 || res.Running[0] != 0 || res.Running[1] != 2 {
+			t.Errorf("expected workers [0 2] cancelled, got %v (%d)", res.Running, res.Cancelled)
+		}
+	})
+
+	t.Run("parent_timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		res, err := RaceDetailed(ctx, slow, slow)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+		if res.Index != IndexCancelled || res.Cancelled != 2 {
+			t.Errorf("expected no winner and 2 cancelled, got index %d and %d", res.Index, res.Cancelled)
+		}
+	})
+}
+
+func TestRaceVerbose(t *testing.T) {
+	ctx := context.Background()
+	w1 := func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "partial", ctx.Err()
+	}
+	w2 := func(ctx context.Context) (string, error) { return "win", nil }
+
+	winner, all, err := RaceVerbose(ctx, w1, w2)
+	if err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+	if winner.Index != 1 || winner.Value != "win" {
+		t.Errorf("expected worker 1 to win, got %v", winner)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(all))
+	}
+	if all[0].Index != 0 || all[0].Value != "partial" || !errors.Is(all[0].Err, context.Canceled) {
+		t.Errorf("expected the loser's final state, got %v", all[0])
+	}
+	if all[1].Value != "win" {
+		t.Errorf("expected the winner's slot to hold the winner, got %v", all[1])
+	}
+}
+
+func TestRaceSuccess(t *testing.T) {
+	t.Run("skips_early_errors", func(t *testing.T) {
+		ctx := context.Background()
+
+		w1 := func(ctx context.Context) (string, error) {
+			return "", errors.New("mirror down")
+		}
+		w2 := func(ctx context.Context) (string, error) {
+			time.Sleep(50 * time.Millisecond)
+			return "mirror-2", nil
+		}
+		w3 := func(ctx context.Context) (string, error) {
+			select {
+			case <-time.After(500 * time.Millisecond):
+				return "mirror-3", nil
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		res, err := RaceSuccess(ctx, w1, w2, w3)
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if res.Value != "mirror-2" || res.Index != 1 {
+			t.Errorf("expected mirror-2 at index 1, got %v at %d", res.Value, res.Index)
+		}
+	})
+
+	t.Run("all_fail", func(t *testing.T) {
+		ctx := context.Background()
+		err1 := errors.New("err1")
+		err2 := errors.New("err2")
+
+		w1 := func(ctx context.Context) (int, error) {
+			time.Sleep(30 * time.Millisecond)
+			return 0, err1
+		}
+		w2 := func(ctx context.Context) (int, error) { return 0, err2 }
+
+		_, err := RaceSuccess(ctx, w1, w2)
+		merr, ok := err.(*MultiError[int])
+		if !ok {
+			t.Fatalf("expected *MultiError[int], got %T", err)
+		}
+		if len(merr.Results) != 2 {
+			t.Fatalf("expected 2 error results, got %d", len(merr.Results))
+		}
+		if merr.Results[0].Err != err1 || merr.Results[1].Err != err2 {
+			t.Errorf("expected errors in index order, got %v", merr.Results)
+		}
+	})
+
+	t.Run("loser_values_dropped", func(t *testing.T) {
+		ctx := context.Background()
+
+		partial := func(ctx context.Context) ([]byte, error) {
+			return make([]byte, 1<<20), errors.New("truncated")
+		}
+		_, err := RaceSuccess(ctx, partial, partial)
+		var merr *MultiError[[]byte]
+		if !errors.As(err, &merr) {
+			t.Fatalf("expected *MultiError, got %v", err)
+		}
+		for _, res := range merr.Results {
+			if res.Value != nil {
+				t.Errorf("expected the failed worker %d to hold no value", res.Index)
+			}
+		}
+	})
+}
+
+func TestFirst(t *testing.T) {
+	delayed := func(d time.Duration, v int, err error) Worker[int] {
+		return func(ctx context.Context) (int, error) {
+			select {
+			case <-time.After(d):
+				return v, err
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+	}
+
+	t.Run("quorum_in_completion_order", func(t *testing.T) {
+		ctx := context.Background()
+		results, err := First(ctx, 2,
+			delayed(300*time.Millisecond, 0, nil),
+			delayed(60*time.Millisecond, 1, nil),
+			delayed(10*time.Millisecond, 2, errors.New("replica down")),
+			delayed(30*time.Millisecond, 3, nil),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(results))
+		}
+		if results[0].Index != 3 || results[1].Index != 1 {
+			t.Errorf("expected completion order [3 1], got [%d %d]", results[0].Index, results[1].Index)
+		}
+	})
+
+	t.Run("not_enough_successes", func(t *testing.T) {
+		ctx := context.Background()
+		results, err := First(ctx, 2,
+			delayed(10*time.Millisecond, 0, errors.New("a")),
+			delayed(20*time.Millisecond, 1, nil),
+			delayed(30*time.Millisecond, 2, errors.New("c")),
+		)
+		merr, ok := err.(*MultiError[int])
+		if !ok {
+			t.Fatalf("expected *MultiError[int], got %T", err)
+		}
+		if len(merr.Results) != 2 {
+			t.Errorf("expected 2 failures, got %d", len(merr.Results))
+		}
+		if len(results) != 1 || results[0].Index != 1 {
+			t.Errorf("expected the single success to be returned, got %v", results)
+		}
+	})
 }
 
 func TestNoRace(t *testing.T) {
@@ -155,4 +381,639 @@ func TestNoRace(t *testing.T) {
 			t.Errorf("Group A values mismatch")
 		}
 	})
+
+	t.Run("panic_is_recovered", func(t *testing.T) {
+		ctx := context.Background()
+		w1 := func(ctx context.Context) (int, error) { return 1, nil }
+		w2 := func(ctx context.Context) (int, error) { panic("kaboom") }
+
+		results, err := NoRace(ctx, w1, w2)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if results[0].Value != 1 || results[0].Err != nil {
+			t.Errorf("expected worker 0 to succeed, got %v", results[0])
+		}
+
+		var perr *PanicError
+		if !errors.As(results[1].Err, &perr) {
+			t.Fatalf("expected *PanicError, got %T", results[1].Err)
+		}
+		if perr.Value != "kaboom" {
+			t.Errorf("expected panic value 'kaboom', got %v", perr.Value)
+		}
+		if len(perr.Stack) == 0 {
+			t.Errorf("expected a captured stack trace")
+		}
+	})
+}
+
+func TestNoRaceFailFast(t *testing.T) {
+	t.Run("stops_on_first_error", func(t *testing.T) {
+		ctx := context.Background()
+		expectedErr := errors.New("abort")
+		var cancelled int32
+
+		w1 := func(ctx context.Context) (int, error) { return 1, nil }
+		w2 := func(ctx context.Context) (int, error) {
+			time.Sleep(30 * time.Millisecond)
+			return 0, expectedErr
+		}
+		w3 := func(ctx context.Context) (int, error) {
+			select {
+			case <-time.After(500 * time.Millisecond):
+				return 3, nil
+			case <-ctx.Done():
+				atomic.AddInt32(&cancelled, 1)
+				return 0, ctx.Err()
+			}
+		}
+
+		start := time.Now()
+		results, err := NoRaceFailFast(ctx, w1, w2, w3)
+		if err != expectedErr {
+			t.Errorf("expected %v, got %v", expectedErr, err)
+		}
+		if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+			t.Errorf("expected early return, took %v", elapsed)
+		}
+		if results[0].Value != 1 || results[0].Err != nil {
+			t.Errorf("expected worker 0 to be kept, got %v", results[0])
+		}
+		if results[1].Err != expectedErr {
+			t.Errorf("expected worker 1 to carry the triggering error, got %v", results[1].Err)
+		}
+		if results[2].Err != ErrUnfinished || results[2].Index != 2 {
+			t.Errorf("expected worker 2 to be marked unfinished, got %v", results[2])
+		}
+		if !results[2].Started {
+			t.Errorf("expected worker 2, abandoned mid-run, to be reported as started")
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		if atomic.LoadInt32(&cancelled) != 1 {
+			t.Errorf("expected worker 2 to be cancelled")
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		block := func(ctx context.Context) (int, error) {
+			time.Sleep(200 * time.Millisecond) // Ignores cancellation
+			return 1, nil
+		}
+
+		for name, run := range map[string]func() ([]Result[int], error){
+			"fail_fast":  func() ([]Result[int], error) { return NoRaceFailFast(ctx, block) },
+			"max_errors": func() ([]Result[int], error) { return NoRaceMaxErrors(ctx, 2, block) },
+			"decider": func() ([]Result[int], error) {
+				return NoRaceWith(ctx, []Option[int]{WithDecider(failed[int])}, block)
+			},
+		} {
+			results, err := run()
+			if !errors.Is(err, ErrCancelled) || !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("%s: expected ErrCancelled wrapping the deadline, got %v", name, err)
+			}
+			if results[0].Err != ErrUnfinished {
+				t.Errorf("%s: expected the worker to be unfinished, got %v", name, results[0])
+			}
+		}
+	})
+
+	t.Run("all_succeed", func(t *testing.T) {
+		ctx := context.Background()
+		results, err := NoRaceFailFast(ctx,
+			func(ctx context.Context) (int, error) { return 1, nil },
+			func(ctx context.Context) (int, error) { return 2, nil },
+		)
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if results[0].Value != 1 || results[1].Value != 2 {
+			t.Errorf("values mismatch: %v", results)
+		}
+	})
+}
+
+func TestRaceOrTimeout(t *testing.T) {
+	ctx := context.Background()
+	var cancelled int32
+	slow := func(ctx context.Context) (int, error) {
+		select {
+		case <-time.After(time.Second):
+			return 1, nil
+		case <-ctx.Done():
+			atomic.AddInt32(&cancelled, 1)
+			return 0, ctx.Err()
+		}
+	}
+
+	t.Run("timeout", func(t *testing.T) {
+		res, err := RaceOrTimeout(ctx, 20*time.Millisecond, slow, slow)
+		if err != ErrRaceTimeout || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected ErrRaceTimeout, got %v", err)
+		}
+		if res.Index != IndexCancelled {
+			t.Errorf("expected index %d, got %d", IndexCancelled, res.Index)
+		}
+		time.Sleep(20 * time.Millisecond)
+		if c := atomic.LoadInt32(&cancelled); c != 2 {
+			t.Errorf("expected both workers to be cancelled, got %d", c)
+		}
+	})
+
+	t.Run("in_time", func(t *testing.T) {
+		fast := func(ctx context.Context) (int, error) { return 7, nil }
+		if res, err := RaceOrTimeout(ctx, time.Second, slow, fast); err != nil || res.Value != 7 {
+			t.Errorf("expected 7, got %+v, %v", res, err)
+		}
+	})
+
+	t.Run("worker_error", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		fail := func(ctx context.Context) (int, error) { return 0, errBoom }
+		if _, err := RaceOrTimeout(ctx, time.Second, fail); err != errBoom {
+			t.Errorf("expected the worker's error, got %v", err)
+		}
+	})
+}
+
+func TestResultStarted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	zero := func(ctx context.Context) (int, error) {
+		cancel()
+		return 0, nil
+	}
+	outs, err := Map(ctx, 1, []int{1, 2}, func(ctx context.Context, n int) (int, error) { return zero(ctx) })
+	merr, ok := err.(*MultiError[int])
+	if !ok || len(outs) != 2 {
+		t.Fatalf("expected a MultiError for the unstarted item, got %v", err)
+	}
+	if merr.Results[0].Started {
+		t.Error("expected the unstarted item to report Started false")
+	}
+
+	results, _ := NoRace(context.Background(), zero)
+	if !results[0].Started {
+		t.Error("expected a worker returning a zero value to report Started")
+	}
+}
+
+func TestBackgroundVariants(t *testing.T) {
+	cancelled := make(chan struct{})
+	fast := func(ctx context.Context) (int, error) { return 1, nil }
+	slow := func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return 0, ctx.Err()
+	}
+
+	if res, err := RaceBG(slow, fast); err != nil || res.Value != 1 {
+		t.Errorf("RaceBG: expected 1, got %+v, %v", res, err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("RaceBG: expected the loser to be cancelled")
+	}
+
+	if results, err := NoRaceBG(fast, fast); err != nil || len(results) != 2 {
+		t.Errorf("NoRaceBG: expected 2 results, got %v, %v", results, err)
+	}
+}
+
+func TestRaceTimed(t *testing.T) {
+	t.Run("winner", func(t *testing.T) {
+		fast := func(ctx context.Context) (int, error) {
+			time.Sleep(20 * time.Millisecond)
+			return 1, nil
+		}
+		slow := func(ctx context.Context) (int, error) {
+			select {
+			case <-time.After(time.Second):
+				return 2, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+
+		res, d, err := RaceTimed(context.Background(), slow, fast)
+		if err != nil || res.Value != 1 {
+			t.Fatalf("expected the fast worker to win, got %+v, %v", res, err)
+		}
+		if d < 20*time.Millisecond || d > 500*time.Millisecond {
+			t.Errorf("expected a duration of about 20ms, got %v", d)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		block := func(ctx context.Context) (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 0, nil
+		}
+
+		res, d, err := RaceTimed(ctx, block)
+		if !errors.Is(err, context.DeadlineExceeded) || res.Index != IndexCancelled {
+			t.Fatalf("expected the deadline, got %+v, %v", res, err)
+		}
+		if d < 10*time.Millisecond || d > 90*time.Millisecond {
+			t.Errorf("expected a duration of about 10ms, got %v", d)
+		}
+	})
+}
+
+func TestCancelCause(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("race_lost", func(t *testing.T) {
+		causes := make(chan error, 1)
+		fast := func(ctx context.Context) (int, error) { return 1, nil }
+		slow := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return 0, ctx.Err()
+		}
+
+		if _, err := Race(ctx, fast, slow); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cause := <-causes; cause != ErrRaceLost {
+			t.Errorf("expected ErrRaceLost, got %v", cause)
+		}
+	})
+
+	t.Run("sibling_failed", func(t *testing.T) {
+		causes := make(chan error, 1)
+		errBoom := errors.New("boom")
+		fail := func(ctx context.Context) (int, error) { return 0, errBoom }
+		slow := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return 0, ctx.Err()
+		}
+
+		NoRaceFailFast(ctx, fail, slow)
+		cause := <-causes
+		if !errors.Is(cause, ErrSiblingFailed) || !errors.Is(cause, errBoom) {
+			t.Errorf("expected ErrSiblingFailed wrapping boom, got %v", cause)
+		}
+	})
+
+	t.Run("parent_cause_kept", func(t *testing.T) {
+		errStop := errors.New("shutting down")
+		parent, cancel := context.WithCancelCause(ctx)
+		causes := make(chan error, 1)
+		w := func(ctx context.Context) (int, error) {
+			cancel(errStop)
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return 0, ctx.Err()
+		}
+
+		Race(parent, w)
+		if cause := <-causes; cause != errStop {
+			t.Errorf("expected the parent's cause, got %v", cause)
+		}
+	})
+}
+
+func TestMultiErrorUnwrap(t *testing.T) {
+	type codeError struct{ error }
+
+	ctx := context.Background()
+	_, err := NoRace(ctx,
+		func(ctx context.Context) (int, error) { return 0, context.Canceled },
+		func(ctx context.Context) (int, error) { return 1, nil },
+		func(ctx context.Context) (int, error) { return 0, codeError{errors.New("bad code")} },
+	)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected errors.Is to match context.Canceled")
+	}
+	var ce codeError
+	if !errors.As(err, &ce) {
+		t.Errorf("expected errors.As to find codeError")
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("did not expect errors.Is to match DeadlineExceeded")
+	}
+}
+
+func TestMultiErrorAccessors(t *testing.T) {
+	err2 := errors.New("err2")
+	err5 := errors.New("err5")
+	merr := &MultiError[int]{Results: []Result[int]{
+		{Err: err2, Index: 2},
+		{Index: 3},
+		{Err: err5, Index: 5},
+	}}
+
+	errs := merr.Errors()
+	if len(errs) != 2 || errs[0] != err2 || errs[1] != err5 {
+		t.Errorf("expected [err2 err5], got %v", errs)
+	}
+	indices := merr.Indices()
+	if len(indices) != 2 || indices[0] != 2 || indices[1] != 5 {
+		t.Errorf("expected [2 5], got %v", indices)
+	}
+}
+
+func TestMultiErrorCompact(t *testing.T) {
+	merr := &MultiError[int]{Results: []Result[int]{
+		{Err: errors.New("boom"), Index: 1},
+		{Index: 2},
+		{Err: errors.New("timeout"), Index: 3},
+	}}
+	if got, want := merr.Compact(), "2 errors: [1] boom; [3] timeout"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	nested := &MultiError[int]{Results: []Result[int]{{Err: merr, Index: 0, Name: "batch"}}}
+	got := nested.Compact()
+	if strings.Contains(got, "\n") {
+		t.Errorf("expected a single line, got %q", got)
+	}
+	if !strings.HasPrefix(got, `1 error: [0] "batch": multiple errors occurred:`) {
+		t.Errorf("unexpected compact form %q", got)
+	}
+}
+
+func TestNoRaceMaxErrors(t *testing.T) {
+	ctx := context.Background()
+	fail := func(ctx context.Context) (int, error) { return 0, errors.New("bad row") }
+	ok := func(ctx context.Context) (int, error) { return 1, nil }
+	slow := func(ctx context.Context) (int, error) {
+		select {
+		case <-time.After(500 * time.Millisecond):
+			return 1, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	t.Run("below_threshold", func(t *testing.T) {
+		results, err := NoRaceMaxErrors(ctx, 3, ok, fail, ok, fail)
+		var me *MultiError[int]
+		if !errors.As(err, &me) || len(me.Results) != 2 {
+			t.Fatalf("expected MultiError with 2 failures, got %v", err)
+		}
+		if results[0].Value != 1 || results[2].Value != 1 {
+			t.Errorf("expected successful workers to be kept, got %v", results)
+		}
+	})
+
+	t.Run("failures_in_index_order", func(t *testing.T) {
+		late := func(ctx context.Context) (int, error) {
+			time.Sleep(20 * time.Millisecond)
+			return 0, errors.New("late failure")
+		}
+		_, err := NoRaceMaxErrors(ctx, 2, late, fail, slow)
+		var me *MultiError[int]
+		if !errors.As(err, &me) {
+			t.Fatalf("expected MultiError, got %v", err)
+		}
+		if got := me.Indices(); !slices.Equal(got, []int{0, 1}) {
+			t.Errorf("expected failures in index order, got %v", got)
+		}
+	})
+
+	t.Run("threshold_reached", func(t *testing.T) {
+		start := time.Now()
+		results, err := NoRaceMaxErrors(ctx, 2, fail, slow, fail, slow)
+		if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+			t.Errorf("expected early return, took %v", elapsed)
+		}
+		var me *MultiError[int]
+		if !errors.As(err, &me) || len(me.Results) != 2 {
+			t.Fatalf("expected MultiError with 2 failures, got %v", err)
+		}
+		if errors.Is(err, ErrUnfinished) {
+			t.Error("expected unfinished workers to be left out of the error")
+		}
+		if results[1].Err != ErrUnfinished || results[3].Err != ErrUnfinished {
+			t.Errorf("expected slow workers to be marked unfinished, got %v", results)
+		}
+		if !results[1].Started || !results[3].Started {
+			t.Errorf("expected the abandoned slow workers to be reported as started, got %v", results)
+		}
+	})
+}
+
+func TestNoRaceDeadline(t *testing.T) {
+	ctx := context.Background()
+	ownErr := errors.New("own failure")
+
+	w1 := func(ctx context.Context) (int, error) { return 1, nil }
+	w2 := func(ctx context.Context) (int, error) { return 0, ownErr }
+	w3 := func(ctx context.Context) (int, error) {
+		time.Sleep(500 * time.Millisecond) // Ignores cancellation
+		return 3, nil
+	}
+
+	start := time.Now()
+	results, err := NoRaceDeadline(ctx, time.Now().Add(50*time.Millisecond), w1, w2, w3)
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("expected return at the deadline, took %v", elapsed)
+	}
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if results[0].Value != 1 || results[0].Err != nil {
+		t.Errorf("expected worker 0 to keep its result, got %v", results[0])
+	}
+	if results[1].Err != ownErr || errors.Is(results[1].Err, ErrDeadlineCutoff) {
+		t.Errorf("expected worker 1 to keep its own error, got %v", results[1].Err)
+	}
+	if !errors.Is(results[2].Err, ErrDeadlineCutoff) || !errors.Is(results[2].Err, context.DeadlineExceeded) {
+		t.Errorf("expected worker 2 to be cut off, got %v", results[2].Err)
+	}
+	if !results[2].Started {
+		t.Errorf("expected the cut-off worker to be reported as started")
+	}
+}
+
+func TestCompletionOrder(t *testing.T) {
+	ctx := context.Background()
+	var workers []Worker[int]
+	for _, d := range []time.Duration{40, 0, 20} {
+		workers = append(workers, func(ctx context.Context) (int, error) {
+			time.Sleep(d * time.Millisecond)
+			return 0, nil
+		})
+	}
+
+	results, err := NoRace(ctx, workers...)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	want := []int{2, 0, 1}
+	for i, r := range results {
+		if r.CompletionOrder != want[i] {
+			t.Errorf("worker %d: expected rank %d, got %d", i, want[i], r.CompletionOrder)
+		}
+	}
+
+	seen := map[int]bool{}
+	for r := range Stream(ctx, workers...) {
+		if seen[r.CompletionOrder] {
+			t.Errorf("duplicate rank %d", r.CompletionOrder)
+		}
+		seen[r.CompletionOrder] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected 3 distinct ranks, got %v", seen)
+	}
+}
+
+func TestNoRaceNamed(t *testing.T) {
+	ctx := context.Background()
+	results, err := NoRaceNamed(ctx,
+		NamedWorker[int]{Name: "fetch-user", Worker: func(ctx context.Context) (int, error) { return 1, nil }},
+		NamedWorker[int]{Name: "fetch-orders", Worker: func(ctx context.Context) (int, error) {
+			return 0, errors.New("connection refused")
+		}},
+	)
+
+	if results[0].Name != "fetch-user" || results[1].Name != "fetch-orders" {
+		t.Errorf("expected names on results, got %q and %q", results[0].Name, results[1].Name)
+	}
+	want := "multiple errors occurred:\n - Worker [1] \"fetch-orders\": connection refused"
+	if err == nil || err.Error() != want {
+		t.Errorf("expected %q, got %v", want, err)
+	}
+}
+
+func TestNoRaceSorted(t *testing.T) {
+	score := func(n int) Worker[int] {
+		return func(ctx context.Context) (int, error) {
+			if n < 0 {
+				return n, errors.New("no score")
+			}
+			return n, nil
+		}
+	}
+	desc := func(a, b int) bool {
+		if a < 0 || b < 0 {
+			t.Errorf("expected less to see successful values only, got %d and %d", a, b)
+		}
+		return a > b
+	}
+
+	results, err := NoRaceSorted(context.Background(), desc, score(3), score(-1), score(7), score(3), score(5))
+
+	var got []int
+	for _, r := range results {
+		got = append(got, r.Index)
+	}
+	if want := []int{2, 4, 0, 3}; !slices.Equal(got, want) {
+		t.Errorf("expected indices %v best-first with ties in index order, got %v", want, got)
+	}
+	merr, ok := err.(*MultiError[int])
+	if !ok || len(merr.Results) != 1 || merr.Results[0].Index != 1 {
+		t.Errorf("expected worker 1 in the MultiError, got %v", err)
+	}
+}
+
+func TestSequential(t *testing.T) {
+	t.Run("runs_in_order", func(t *testing.T) {
+		ctx := context.Background()
+		var order []int
+		var workers []Worker[int]
+		for i := range 3 {
+			workers = append(workers, func(ctx context.Context) (int, error) {
+				order = append(order, i) // No lock needed: one worker at a time
+				if i == 1 {
+					return 0, errors.New("boom")
+				}
+				return i, nil
+			})
+		}
+
+		results, err := Sequential(ctx, workers...)
+		merr, ok := err.(*MultiError[int])
+		if !ok {
+			t.Fatalf("expected *MultiError[int], got %T", err)
+		}
+		if len(merr.Results) != 1 || merr.Results[0].Index != 1 {
+			t.Errorf("expected worker 1 to fail, got %v", merr.Results)
+		}
+		if len(order) != 3 || order[0] != 0 || order[1] != 1 || order[2] != 2 {
+			t.Errorf("expected sequential order, got %v", order)
+		}
+		if results[2].Value != 2 {
+			t.Errorf("expected later workers to run after a failure, got %v", results[2])
+		}
+	})
+
+	t.Run("cancel_between_workers", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		w1 := func(ctx context.Context) (int, error) {
+			cancel()
+			return 1, nil
+		}
+		w2 := func(ctx context.Context) (int, error) {
+			t.Errorf("expected worker 1 not to run")
+			return 2, nil
+		}
+
+		results, _ := Sequential(ctx, w1, w2)
+		if results[0].Value != 1 || !errors.Is(results[1].Err, context.Canceled) {
+			t.Errorf("unexpected results: %v", results)
+		}
+	})
+}
+
+func TestResultTimestamps(t *testing.T) {
+	t.Run("noRace", func(t *testing.T) {
+		ctx := context.Background()
+		before := time.Now()
+		results, err := NoRace(ctx,
+			func(ctx context.Context) (int, error) {
+				time.Sleep(20 * time.Millisecond)
+				return 1, nil
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		r := results[0]
+		if r.StartedAt.Before(before) || r.FinishedAt.Before(r.StartedAt) {
+			t.Errorf("unexpected timestamps: %v -> %v", r.StartedAt, r.FinishedAt)
+		}
+		if d := r.FinishedAt.Sub(r.StartedAt); d < 20*time.Millisecond {
+			t.Errorf("expected duration >= 20ms, got %v", d)
+		}
+	})
+
+	t.Run("race_and_stream", func(t *testing.T) {
+		ctx := context.Background()
+		w := func(ctx context.Context) (int, error) { return 1, nil }
+
+		res, _ := Race(ctx, w)
+		if res.StartedAt.IsZero() || res.FinishedAt.IsZero() {
+			t.Errorf("expected Race to record timestamps, got %v", res)
+		}
+		for r := range Stream(ctx, w) {
+			if r.StartedAt.IsZero() || r.FinishedAt.IsZero() {
+				t.Errorf("expected Stream to record timestamps, got %v", r)
+			}
+		}
+	})
+
+	t.Run("never_started", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results, _ := Sequential(ctx, func(ctx context.Context) (int, error) { return 1, nil })
+		if !results[0].StartedAt.IsZero() {
+			t.Errorf("expected zero StartedAt for a worker that never started")
+		}
+	})
 }
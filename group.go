@@ -0,0 +1,112 @@
+package gocrc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Group runs workers added one at a time, in the spirit of errgroup, and collects their Results.
+// All workers share one cancellable context derived from the one given to NewGroup.
+// A Group must not be reused after Wait.
+type Group[T any] struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	sem    chan struct{}
+
+	mu            sync.Mutex
+	results       []Result[T]
+	completed     int
+	cancelOnError bool
+}
+
+// NewGroup returns a Group whose workers run under a context derived from ctx.
+func NewGroup[T any](ctx context.Context) *Group[T] {
+	groupCtx, cancel := context.WithCancelCause(ctx)
+	return &Group[T]{ctx: groupCtx, cancel: cancel}
+}
+
+// SetCancelOnError controls whether the first failing worker cancels the shared context
+// of its siblings. It is off by default and should be set before calling Go.
+func (g *Group[T]) SetCancelOnError(cancel bool) {
+	g.mu.Lock()
+	g.cancelOnError = cancel
+	g.mu.Unlock()
+}
+
+// SetLimit limits the number of active workers to at most n. A negative n removes the limit.
+// Once the limit is reached, Go blocks until a worker returns. The limit must not be changed while
+// any worker is active.
+func (g *Group[T]) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic(fmt.Errorf("gocrc: modify limit while %d workers in the group are still active", len(g.sem)))
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go starts w in a new goroutine, blocking first while the limit set by SetLimit is reached.
+// Its Result takes the next Index, in the order workers were started.
+func (g *Group[T]) Go(w Worker[T]) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.start(w)
+}
+
+// TryGo starts w only if doing so does not exceed the limit set by SetLimit, and reports whether it did.
+func (g *Group[T]) TryGo(w Worker[T]) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.start(w)
+	return true
+}
+
+func (g *Group[T]) start(w Worker[T]) {
+	g.mu.Lock()
+	index := len(g.results)
+	g.results = append(g.results, Result[T]{Index: index})
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer g.release()
+		res := invoke(g.ctx, index, w)
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		res.CompletionOrder = g.completed
+		g.results[index] = res
+		g.completed++
+		if res.Err != nil && g.cancelOnError {
+			g.cancel(siblingFailed(res))
+		}
+	}()
+}
+
+func (g *Group[T]) release() {
+	if g.sem != nil {
+		<-g.sem
+	}
+}
+
+// Wait blocks until every worker started with Go has returned, then cancels the shared context.
+// It follows the contract of NoRace: all Results in order, plus a MultiError if any worker failed.
+func (g *Group[T]) Wait() ([]Result[T], error) {
+	g.wg.Wait()
+	g.cancel(nil)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.results, collectErrors(g.results)
+}
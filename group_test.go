@@ -0,0 +1,131 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("incremental_workers", func(t *testing.T) {
+		g := NewGroup[int](context.Background())
+		for i := range 4 {
+			g.Go(func(ctx context.Context) (int, error) {
+				time.Sleep(time.Duration(4-i) * time.Millisecond)
+				return i * i, nil
+			})
+		}
+
+		results, err := g.Wait()
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if len(results) != 4 {
+			t.Fatalf("expected 4 results, got %d", len(results))
+		}
+		for i, r := range results {
+			if r.Index != i || r.Value != i*i {
+				t.Errorf("result %d mismatch: %v", i, r)
+			}
+		}
+	})
+
+	t.Run("errors_without_cancel", func(t *testing.T) {
+		g := NewGroup[string](context.Background())
+		g.Go(func(ctx context.Context) (string, error) { return "", errors.New("boom") })
+		g.Go(func(ctx context.Context) (string, error) {
+			select {
+			case <-time.After(30 * time.Millisecond):
+				return "done", nil
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		})
+
+		results, err := g.Wait()
+		merr, ok := err.(*MultiError[string])
+		if !ok {
+			t.Fatalf("expected *MultiError[string], got %T", err)
+		}
+		if len(merr.Results) != 1 || merr.Results[0].Index != 0 {
+			t.Errorf("expected worker 0 to fail, got %v", merr.Results)
+		}
+		if results[1].Value != "done" {
+			t.Errorf("expected sibling to finish, got %v", results[1])
+		}
+	})
+
+	t.Run("cancel_on_error", func(t *testing.T) {
+		g := NewGroup[string](context.Background())
+		g.SetCancelOnError(true)
+		g.Go(func(ctx context.Context) (string, error) { return "", errors.New("boom") })
+		g.Go(func(ctx context.Context) (string, error) {
+			select {
+			case <-time.After(time.Second):
+				return "done", nil
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		})
+
+		results, _ := g.Wait()
+		if !errors.Is(results[1].Err, context.Canceled) {
+			t.Errorf("expected sibling to be cancelled, got %v", results[1].Err)
+		}
+	})
+}
+
+func TestGroupSetLimit(t *testing.T) {
+	t.Run("go_blocks_at_limit", func(t *testing.T) {
+		g := NewGroup[int](context.Background())
+		g.SetLimit(2)
+
+		var inFlight, peak int32
+		for i := range 6 {
+			g.Go(func(ctx context.Context) (int, error) {
+				cur := atomic.AddInt32(&inFlight, 1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+				return i, nil
+			})
+		}
+
+		results, err := g.Wait()
+		if err != nil || len(results) != 6 {
+			t.Fatalf("expected 6 results and no error, got %d, %v", len(results), err)
+		}
+		if p := atomic.LoadInt32(&peak); p > 2 {
+			t.Errorf("expected at most 2 active workers, got %d", p)
+		}
+	})
+
+	t.Run("try_go", func(t *testing.T) {
+		g := NewGroup[int](context.Background())
+		g.SetLimit(1)
+
+		release := make(chan struct{})
+		if !g.TryGo(func(ctx context.Context) (int, error) {
+			<-release
+			return 1, nil
+		}) {
+			t.Fatal("expected the first TryGo to start")
+		}
+		if g.TryGo(func(ctx context.Context) (int, error) { return 2, nil }) {
+			t.Errorf("expected TryGo to refuse while the slot is taken")
+		}
+
+		close(release)
+		results, _ := g.Wait()
+		if len(results) != 1 || results[0].Value != 1 {
+			t.Errorf("expected only the first worker to run, got %v", results)
+		}
+	})
+}
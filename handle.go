@@ -0,0 +1,67 @@
+package gocrc
+
+import "context"
+
+// RaceHandle is a race running in the background, started by StartRace. It lets callers that cannot
+// block, such as custom event loops, poll for the winner or select on Done.
+type RaceHandle[T any] struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	res    Result[T]
+	err    error
+}
+
+// StartRace starts a Race in the background and returns at once.
+func StartRace[T any](ctx context.Context, workers ...Worker[T]) *RaceHandle[T] {
+	raceCtx, cancel := context.WithCancel(ctx)
+	h := &RaceHandle[T]{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer cancel()
+		h.res, h.err = Race(raceCtx, workers...)
+		close(h.done)
+	}()
+	return h
+}
+
+// Try returns the winning Result, whose Err holds the error Race would return, without blocking.
+// ok is false while the race is still running, in which case Try may simply be called again later.
+func (h *RaceHandle[T]) Try() (res Result[T], ok bool) {
+	select {
+	case <-h.done:
+		return h.res, true
+	default:
+		return Result[T]{}, false
+	}
+}
+
+// Done returns a channel that is closed once the race is over, for use in a select.
+func (h *RaceHandle[T]) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait blocks until the race is over and returns its outcome, like Race.
+func (h *RaceHandle[T]) Wait() (Result[T], error) {
+	<-h.done
+	return h.res, h.err
+}
+
+// Cancel cancels the race. The outcome, available from Wait, then carries the context error unless
+// a worker had already won.
+func (h *RaceHandle[T]) Cancel() {
+	h.cancel()
+}
+
+// NoRaceAsync starts a NoRace in the background and returns at once. The channel delivers exactly one
+// value, the final Results in index order, and is then closed; use FirstError or AllErrors for the
+// failures. The cancel function cancels the workers' context; it should be called once the Results are
+// no longer needed to release resources.
+func NoRaceAsync[T any](ctx context.Context, workers ...Worker[T]) (<-chan []Result[T], context.CancelFunc) {
+	asyncCtx, cancel := context.WithCancel(ctx)
+	out := make(chan []Result[T], 1)
+	go func() {
+		defer close(out)
+		results, _ := NoRace(asyncCtx, workers...)
+		out <- results
+	}()
+	return out, cancel
+}
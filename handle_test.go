@@ -0,0 +1,81 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStartRace(t *testing.T) {
+	t.Run("poll", func(t *testing.T) {
+		release := make(chan struct{})
+		w := func(ctx context.Context) (int, error) {
+			<-release
+			return 7, nil
+		}
+
+		h := StartRace(context.Background(), w)
+		if _, ok := h.Try(); ok {
+			t.Fatal("expected the race to be running")
+		}
+
+		close(release)
+		select {
+		case <-h.Done():
+		case <-time.After(time.Second):
+			t.Fatal("expected the race to finish")
+		}
+		res, ok := h.Try()
+		if !ok || res.Value != 7 || res.Err != nil {
+			t.Errorf("expected 7, got %+v, %v", res, ok)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		block := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+
+		h := StartRace(context.Background(), block)
+		h.Cancel()
+		if _, err := h.Wait(); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestNoRaceAsync(t *testing.T) {
+	t.Run("delivers_once", func(t *testing.T) {
+		w := func(ctx context.Context) (int, error) { return 1, nil }
+		ch, cancel := NoRaceAsync(context.Background(), w, w)
+		defer cancel()
+
+		results, ok := <-ch
+		if !ok || len(results) != 2 || results[1].Value != 1 {
+			t.Fatalf("expected 2 results, got %v", results)
+		}
+		if _, ok := <-ch; ok {
+			t.Error("expected the channel to be closed after the results")
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		block := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		ch, cancel := NoRaceAsync(context.Background(), block, block)
+		cancel()
+
+		select {
+		case results := <-ch:
+			if !errors.Is(FirstError(results), context.Canceled) {
+				t.Errorf("expected context.Canceled, got %v", FirstError(results))
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected cancel to stop the workers")
+		}
+	})
+}
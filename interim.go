@@ -0,0 +1,53 @@
+package gocrc
+
+import (
+	"context"
+	"sync"
+)
+
+// interimKey is the context key under which RaceInterim stores the publisher of interim values.
+type interimKey struct{}
+
+// Publish offers v as the running worker's best value so far, for RaceInterim to fall back on if
+// its context is done before any worker completes. It reports whether v was accepted, which it is
+// only under a RaceInterim of the same type T; elsewhere Publish is a cheap no-op.
+func Publish[T any](ctx context.Context, v T) bool {
+	publish, ok := ctx.Value(interimKey{}).(func(context.Context, T))
+	if !ok {
+		return false
+	}
+	publish(ctx, v)
+	return true
+}
+
+// RaceInterim is like Race, for progressive workers that call Publish with intermediate values.
+// If ctx is done, typically by its deadline, before any worker has completed, the most recently
+// published value is returned as a Partial Result of the worker that published it, with a nil error,
+// instead of ctx.Err(). Without any published value it behaves like Race.
+func RaceInterim[T any](ctx context.Context, workers ...Worker[T]) (Result[T], error) {
+	var mu sync.Mutex
+	var latest Result[T]
+	var published bool
+
+	publish := func(workerCtx context.Context, v T) {
+		index, _ := IndexFromContext(workerCtx)
+		name, _ := NameFromContext(workerCtx)
+
+		mu.Lock()
+		defer mu.Unlock()
+		latest = Result[T]{Value: v, Index: index, Name: name, Partial: true, Started: true}
+		published = true
+	}
+
+	res, err := Race(context.WithValue(ctx, interimKey{}, publish), workers...)
+	if err == nil || ctx.Err() == nil {
+		return res, err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !published {
+		return res, err
+	}
+	return latest, nil
+}
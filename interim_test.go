@@ -0,0 +1,64 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRaceInterim(t *testing.T) {
+	progressive := func(ctx context.Context) (int, error) {
+		for i := 1; ; i++ {
+			Publish(ctx, i)
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}
+	slow := func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+
+	t.Run("deadline_returns_interim", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		res, err := RaceInterim(ctx, slow, progressive)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !res.Partial || res.Index != 1 || res.Value < 1 {
+			t.Errorf("expected a partial value from worker 1, got %+v", res)
+		}
+	})
+
+	t.Run("winner_preferred", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		fast := func(ctx context.Context) (int, error) { return 42, nil }
+		res, err := RaceInterim(ctx, progressive, fast)
+		if err != nil || res.Value != 42 || res.Partial {
+			t.Errorf("expected the completed winner, got %+v, %v", res, err)
+		}
+	})
+
+	t.Run("nothing_published", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if _, err := RaceInterim(ctx, slow); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded, got %v", err)
+		}
+	})
+
+	t.Run("publish_outside_race", func(t *testing.T) {
+		if Publish(context.Background(), 1) {
+			t.Error("expected Publish to report false outside RaceInterim")
+		}
+	})
+}
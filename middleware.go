@@ -0,0 +1,37 @@
+package gocrc
+
+import "time"
+
+// Middleware wraps a worker with extra behaviour, like the With* wrappers of this package.
+type Middleware[T any] func(Worker[T]) Worker[T]
+
+// Chain wraps w in the middlewares, the first one outermost: Chain(w, a, b) is a(b(w)), so a call
+// passes through a, then b, then reaches w. For example, Chain(w, Retry[T](3), Timeout[T](time.Second))
+// gives each of up to three attempts its own one-second timeout.
+func Chain[T any](w Worker[T], middlewares ...Middleware[T]) Worker[T] {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		w = middlewares[i](w)
+	}
+	return w
+}
+
+// Timeout is the Middleware form of WithTimeout.
+func Timeout[T any](d time.Duration) Middleware[T] {
+	return func(w Worker[T]) Worker[T] {
+		return WithTimeout(d, w)
+	}
+}
+
+// Retry is the Middleware form of WithRetry.
+func Retry[T any](attempts int) Middleware[T] {
+	return func(w Worker[T]) Worker[T] {
+		return WithRetry(attempts, w)
+	}
+}
+
+// Backoff is the Middleware form of WithBackoffConfig.
+func Backoff[T any](cfg BackoffConfig) Middleware[T] {
+	return func(w Worker[T]) Worker[T] {
+		return WithBackoffConfig(cfg, w)
+	}
+}
@@ -0,0 +1,58 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChain(t *testing.T) {
+	t.Run("order", func(t *testing.T) {
+		var trace []string
+		tag := func(name string) Middleware[int] {
+			return func(w Worker[int]) Worker[int] {
+				return func(ctx context.Context) (int, error) {
+					trace = append(trace, name)
+					return w(ctx)
+				}
+			}
+		}
+		w := func(ctx context.Context) (int, error) {
+			trace = append(trace, "worker")
+			return 1, nil
+		}
+
+		if _, err := Chain(w, tag("a"), tag("b"))(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(trace) != 3 || trace[0] != "a" || trace[1] != "b" || trace[2] != "worker" {
+			t.Errorf("expected [a b worker], got %v", trace)
+		}
+	})
+
+	t.Run("timeout_per_attempt", func(t *testing.T) {
+		calls := 0
+		w := func(ctx context.Context) (int, error) {
+			calls++
+			if calls < 3 {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+			return 7, nil
+		}
+
+		val, err := Chain(w, Retry[int](3), Timeout[int](10*time.Millisecond))(context.Background())
+		if err != nil || val != 7 || calls != 3 {
+			t.Errorf("expected 7 after 3 calls, got %v, %v after %d", val, err, calls)
+		}
+	})
+
+	t.Run("no_middlewares", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		w := func(ctx context.Context) (int, error) { return 0, errBoom }
+		if _, err := Chain(w)(context.Background()); err != errBoom {
+			t.Errorf("expected the worker itself, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,455 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// Option configures a NoRaceWith call.
+type Option[T any] func(*options[T])
+
+type options[T any] struct {
+	onProgress func(completed, total int)
+	onResult   func(Result[T])
+	onTiming   func(index int, d time.Duration, err error)
+
+	returnOnCancel bool
+	limiter        Limiter
+	names          []string
+	logger         *slog.Logger
+	softTimeout    time.Duration
+	grace          time.Duration
+	scheduler      Scheduler
+	timeout        func(index int) time.Duration
+	adaptive       *adaptiveLimit[T]
+	burst          *burstLimit
+	onPanic        func(recovered any, index int) error
+	stagger        time.Duration
+	concurrency    *concurrency
+	registry       *Registry
+	groupKey       string
+	wrapErrors     bool
+	resultBuf      []Result[T]
+	signals        []os.Signal
+	transform      func(T) T
+	tracer         Tracer
+	decider        func(Result[T]) bool
+	onStart        func(index int)
+}
+
+func newOptions[T any](opts []Option[T]) *options[T] {
+	o := &options[T]{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// name returns the label of the worker at index, or "" if it has none.
+func (o *options[T]) name(index int) string {
+	if index < len(o.names) {
+		return o.names[index]
+	}
+	return ""
+}
+
+// exec runs the worker at index with the per-worker options applied and returns its Result.
+func (o *options[T]) exec(ctx context.Context, index int, worker Worker[T]) Result[T] {
+	res := Result[T]{Index: index, Name: o.name(index)}
+	if err := o.admit(ctx, index); err != nil {
+		res.Err = err
+		return res
+	}
+	if o.adaptive != nil {
+		if err := o.adaptive.sem.Acquire(ctx, 1); err != nil {
+			res.Err = err
+			return res
+		}
+		defer func() { o.adaptive.done(res) }()
+	}
+	if o.burst != nil {
+		if err := o.burst.acquire(ctx); err != nil {
+			res.Err = err
+			return res
+		}
+		defer o.burst.release()
+	}
+
+	if o.concurrency != nil {
+		o.concurrency.enter()
+		defer o.concurrency.exit()
+	}
+
+	o.logStart(ctx, res)
+	res.StartedAt = time.Now()
+	res.Started = true
+	if o.onStart != nil {
+		o.onStart(index)
+	}
+	workerCtx, span := o.startSpan(withWorker(ctx, index, res.Name), index, res.Name)
+	if o.timeout != nil {
+		if d := o.timeout(index); d > 0 {
+			worker = WithTimeout(d, worker)
+		}
+	}
+	if o.softTimeout > 0 {
+		res.Value, res.Partial, res.Err = o.callSoft(workerCtx, index, worker)
+	} else {
+		res.Value, res.Err = o.call(workerCtx, index, worker)
+	}
+	res.FinishedAt = time.Now()
+	if span != nil {
+		if res.Err != nil {
+			span.RecordError(res.Err)
+		}
+		span.End()
+	}
+	if o.wrapErrors && res.Err != nil {
+		res.Err = wrapWorkerError(res.Index, res.Name, res.Err)
+	}
+	if o.transform != nil && res.Err == nil {
+		res.Value = o.transform(res.Value)
+	}
+	o.logFinish(ctx, res)
+	return res
+}
+
+// admit runs before a worker starts and reports why it must not start, if anything.
+func (o *options[T]) admit(ctx context.Context, index int) error {
+	if o.stagger > 0 {
+		if err := sleep(ctx, time.Duration(index)*o.stagger); err != nil {
+			return err
+		}
+	}
+	if o.limiter != nil {
+		if err := o.limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("%w: %w", ErrRateLimitWait, err)
+		}
+	}
+	return nil
+}
+
+// observe runs the completion hooks for res. Callers serialize calls to observe.
+func (o *options[T]) observe(res Result[T], completed, total int) {
+	if o.onTiming != nil {
+		o.onTiming(res.Index, res.FinishedAt.Sub(res.StartedAt), res.Err)
+	}
+	if o.onResult != nil {
+		o.onResult(res)
+	}
+	if o.onProgress != nil {
+		o.onProgress(completed, total)
+	}
+}
+
+// WithProgress calls fn each time a worker finishes, with the number of completed workers so far
+// and the total. Calls are serialized and the last one reports completed == total.
+func WithProgress[T any](fn func(completed, total int)) Option[T] {
+	return func(o *options[T]) {
+		o.onProgress = fn
+	}
+}
+
+// WithOnResult calls fn once per finished worker, for successful and failed Results alike,
+// before the aggregate is returned. Calls are serialized.
+func WithOnResult[T any](fn func(Result[T])) Option[T] {
+	return func(o *options[T]) {
+		o.onResult = fn
+	}
+}
+
+// WithTiming calls fn once per finished worker with how long the worker body ran.
+// The duration covers only the worker itself, not time spent waiting to be scheduled. Calls are serialized.
+func WithTiming[T any](fn func(index int, d time.Duration, err error)) Option[T] {
+	return func(o *options[T]) {
+		o.onTiming = fn
+	}
+}
+
+// WithReturnOnCancel makes NoRaceWith return as soon as the context is done instead of waiting for
+// every worker. The Results completed so far are returned, unfinished ones carry ErrUnfinished, and
+// the error wraps both ErrCancelled and ctx.Err().
+// Workers still running are abandoned, not awaited: they keep running until they honour the
+// cancellation, and their late Results are discarded without invoking any hooks.
+func WithReturnOnCancel[T any]() Option[T] {
+	return func(o *options[T]) {
+		o.returnOnCancel = true
+	}
+}
+
+// ErrRateLimitWait is reported, wrapping the limiter's error, for a worker whose context ended while
+// it was waiting on the rate limiter.
+var ErrRateLimitWait = errors.New("gocrc: cancelled while waiting for rate limit")
+
+// Limiter paces worker starts. *rate.Limiter from golang.org/x/time/rate satisfies it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimit makes every worker call limiter.Wait before running, capping throughput across
+// the whole batch regardless of concurrency. A worker whose wait fails does not run.
+func WithRateLimit[T any](limiter Limiter) Option[T] {
+	return func(o *options[T]) {
+		o.limiter = limiter
+	}
+}
+
+// WithNames labels the workers by position: names[i] becomes the Name of worker i's Result.
+// Workers beyond the end of names stay unnamed.
+func WithNames[T any](names ...string) Option[T] {
+	return func(o *options[T]) {
+		o.names = names
+	}
+}
+
+// Attribute keys used by WithLogger. They are stable so that dashboards can rely on them.
+const (
+	LogKeyIndex    = "index"
+	LogKeyName     = "name"
+	LogKeyDuration = "duration"
+	LogKeyError    = "error"
+	// LogKeyCorrelationID is only present for contexts with a CorrelationID.
+	LogKeyCorrelationID = "correlation_id"
+)
+
+// WithLogger logs each worker's start, finish and failure at debug level with the LogKey* attributes,
+// including the CorrelationID of the context if it has one. A nil logger disables logging.
+func WithLogger[T any](logger *slog.Logger) Option[T] {
+	return func(o *options[T]) {
+		o.logger = logger
+	}
+}
+
+func (o *options[T]) logStart(ctx context.Context, res Result[T]) {
+	if o.logger == nil {
+		return
+	}
+	o.logger.DebugContext(ctx, "worker started", o.logAttrs(ctx, res)...)
+}
+
+func (o *options[T]) logFinish(ctx context.Context, res Result[T]) {
+	if o.logger == nil {
+		return
+	}
+	attrs := append(o.logAttrs(ctx, res), slog.Duration(LogKeyDuration, res.FinishedAt.Sub(res.StartedAt)))
+	if res.Err != nil {
+		o.logger.DebugContext(ctx, "worker failed", append(attrs, slog.Any(LogKeyError, res.Err))...)
+		return
+	}
+	o.logger.DebugContext(ctx, "worker finished", attrs...)
+}
+
+func (o *options[T]) logAttrs(ctx context.Context, res Result[T]) []any {
+	attrs := []any{slog.Int(LogKeyIndex, res.Index)}
+	if res.Name != "" {
+		attrs = append(attrs, slog.String(LogKeyName, res.Name))
+	}
+	if id, ok := CorrelationID(ctx); ok {
+		attrs = append(attrs, slog.String(LogKeyCorrelationID, id))
+	}
+	return attrs
+}
+
+// WithStartStagger delays the start of worker i by i*interval, spreading out the initial burst of a
+// large batch. Unlike WithRateLimit it only shapes startup; workers are not paced once started.
+// A worker whose context is done while it is still waiting does not run and reports ctx.Err().
+func WithStartStagger[T any](interval time.Duration) Option[T] {
+	return func(o *options[T]) {
+		o.stagger = interval
+	}
+}
+
+// WithTransform applies fn to the Value of every successful worker before it is stored in the Result
+// and seen by any hook, e.g. to normalize or redact it. Failed Results are left untouched.
+// See MapResults for transformations that change the type.
+func WithTransform[T any](fn func(T) T) Option[T] {
+	return func(o *options[T]) {
+		o.transform = fn
+	}
+}
+
+// WithErrorContext wraps each worker's error with the worker's name and index, as in
+// `worker "fetch-user" (index 3): connection refused`, so that the error describes itself wherever it
+// is logged. The original error stays in the chain for errors.Is and errors.As. MultiError prints
+// such errors without its own worker prefix, so the name and index appear once.
+func WithErrorContext[T any]() Option[T] {
+	return func(o *options[T]) {
+		o.wrapErrors = true
+	}
+}
+
+func wrapWorkerError(index int, name string, err error) error {
+	return &workerError{index: index, name: name, err: err}
+}
+
+// workerError is an error annotated by WithErrorContext. MultiError recognizes it so as not to
+// print the worker's name and index twice.
+type workerError struct {
+	index int
+	name  string
+	err   error
+}
+
+func (e *workerError) Error() string {
+	if e.name != "" {
+		return fmt.Sprintf("worker %q (index %d): %v", e.name, e.index, e.err)
+	}
+	return fmt.Sprintf("worker (index %d): %v", e.index, e.err)
+}
+
+func (e *workerError) Unwrap() error {
+	return e.err
+}
+
+// WithResultBuffer makes NoRaceWith store its Results in buf, when buf has enough capacity, instead of
+// allocating a new slice on every call. The returned slice then shares buf's backing array, so the
+// Results of one call must not be used, or retained, once buf is passed to the next call.
+func WithResultBuffer[T any](buf []Result[T]) Option[T] {
+	return func(o *options[T]) {
+		o.resultBuf = buf
+	}
+}
+
+// resultSlice returns a zeroed slice of n Results, reusing the buffer of WithResultBuffer if it fits.
+func (o *options[T]) resultSlice(n int) []Result[T] {
+	if cap(o.resultBuf) < n {
+		return make([]Result[T], n)
+	}
+	results := o.resultBuf[:n]
+	clear(results)
+	return results
+}
+
+// WithSignalCancel cancels the workers' context when one of the signals, such as os.Interrupt, is
+// received while the call runs, so that a CLI tool can stop cleanly on Ctrl-C; the call then returns
+// the Results as usual, those of cancelled workers included. The signals are handled only for the
+// duration of the call, as with signal.NotifyContext, and restored to their previous behaviour after.
+func WithSignalCancel[T any](sig ...os.Signal) Option[T] {
+	return func(o *options[T]) {
+		o.signals = sig
+	}
+}
+
+// scope derives the context of a whole call from ctx. The returned function must be called once the
+// call is over.
+func (o *options[T]) scope(ctx context.Context) (context.Context, func()) {
+	var stops []func()
+	if len(o.signals) > 0 {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, o.signals...)
+		stops = append(stops, stop)
+	}
+	if o.registry != nil {
+		var release func()
+		ctx, release = o.registry.register(ctx, o.groupKey)
+		stops = append(stops, release)
+	}
+	return ctx, func() {
+		for i := len(stops) - 1; i >= 0; i-- {
+			stops[i]()
+		}
+	}
+}
+
+// WithDynamicTimeout runs each worker under its own timeout of fn(index), as if wrapped in
+// WithTimeout, so that e.g. larger inputs can be given longer. A zero or negative duration means
+// no timeout for that worker.
+func WithDynamicTimeout[T any](fn func(index int) time.Duration) Option[T] {
+	return func(o *options[T]) {
+		o.timeout = fn
+	}
+}
+
+// WithPanicHandler lets fn decide what a panicking worker turns into: the error fn returns is
+// stored in the worker's Result, and a nil error re-panics with the recovered value, crashing the
+// program. Without a handler a panic becomes a *PanicError.
+// fn runs on the panicking worker's goroutine, before its Result is recorded and while the other
+// workers keep running, so calls may be concurrent.
+func WithPanicHandler[T any](fn func(recovered any, index int) error) Option[T] {
+	return func(o *options[T]) {
+		o.onPanic = fn
+	}
+}
+
+// call invokes the worker, handing a panic to the panic handler if there is one.
+func (o *options[T]) call(ctx context.Context, index int, worker Worker[T]) (val T, err error) {
+	if o.onPanic == nil {
+		return call(ctx, worker)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			if err = o.onPanic(r, index); err == nil {
+				panic(r)
+			}
+			val = zero
+		}
+	}()
+	return worker(ctx)
+}
+
+// ErrAbandoned is reported for a worker that did not return within its grace period and was
+// abandoned. Its goroutine keeps running until the worker returns on its own, which Stats reports
+// as Leaked.
+var ErrAbandoned = errors.New("gocrc: worker abandoned after grace period")
+
+// WithSoftTimeout cancels each worker's context after timeout but, rather than giving up on it,
+// waits a further grace period for it to return what it has. A worker returning during the grace
+// period has its Result marked Partial and keeps its Value and error. A worker still running once the
+// grace period is over is abandoned and reported with ErrAbandoned.
+func WithSoftTimeout[T any](timeout, grace time.Duration) Option[T] {
+	return func(o *options[T]) {
+		o.softTimeout = timeout
+		o.grace = grace
+	}
+}
+
+// WithEscalatingTimeout is WithSoftTimeout with both stages measured from the worker's start: its
+// context is cancelled after soft, so that a cooperative worker can clean up, and if it still has not
+// returned after hard it is abandoned with ErrAbandoned, leaking its goroutine until it returns.
+// NoRaceWithStats counts such workers in Stats.Leaked. A hard below soft abandons the worker as soon
+// as its context is cancelled.
+func WithEscalatingTimeout[T any](soft, hard time.Duration) Option[T] {
+	return WithSoftTimeout[T](soft, max(hard-soft, 0))
+}
+
+func (o *options[T]) callSoft(ctx context.Context, index int, worker Worker[T]) (T, bool, error) {
+	softCtx, cancel := context.WithTimeout(ctx, o.softTimeout)
+	defer cancel()
+
+	type outcome struct {
+		val T
+		err error
+	}
+	// Buffered so that an abandoned worker can still deliver and exit.
+	done := make(chan outcome, 1)
+	go func() {
+		val, err := o.call(softCtx, index, worker)
+		done <- outcome{val, err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.val, false, out.err
+	case <-softCtx.Done():
+	}
+
+	// Only the soft deadline, not the parent context, makes a late result partial.
+	partial := ctx.Err() == nil
+
+	grace := time.NewTimer(o.grace)
+	defer grace.Stop()
+
+	select {
+	case out := <-done:
+		return out.val, partial, out.err
+	case <-grace.C:
+		var zero T
+		return zero, false, ErrAbandoned
+	}
+}
@@ -0,0 +1,581 @@
+package gocrc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithProgress(t *testing.T) {
+	ctx := context.Background()
+	var calls [][2]int
+
+	var workers []Worker[int]
+	for i := range 5 {
+		workers = append(workers, func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration(i) * time.Millisecond)
+			return i, nil
+		})
+	}
+
+	// The callback appends without a lock: calls must be serialized.
+	_, err := NoRaceWith(ctx, []Option[int]{
+		WithProgress[int](func(completed, total int) {
+			calls = append(calls, [2]int{completed, total})
+		}),
+	}, workers...)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(calls) != 5 {
+		t.Fatalf("expected 5 progress calls, got %d", len(calls))
+	}
+	for i, c := range calls {
+		if c[0] != i+1 || c[1] != 5 {
+			t.Errorf("call %d: expected (%d, 5), got %v", i, i+1, c)
+		}
+	}
+}
+
+func TestWithOnResult(t *testing.T) {
+	ctx := context.Background()
+	seen := map[int]error{}
+
+	_, err := NoRaceWith(ctx, []Option[string]{
+		WithOnResult(func(r Result[string]) {
+			seen[r.Index] = r.Err
+		}),
+	},
+		func(ctx context.Context) (string, error) { return "ok", nil },
+		func(ctx context.Context) (string, error) { return "", errors.New("boom") },
+		func(ctx context.Context) (string, error) { return "ok", nil },
+	)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 observed results, got %d", len(seen))
+	}
+	if seen[0] != nil || seen[1] == nil || seen[2] != nil {
+		t.Errorf("expected only worker 1 to be observed as failed, got %v", seen)
+	}
+}
+
+func TestWithTiming(t *testing.T) {
+	ctx := context.Background()
+	durations := map[int]time.Duration{}
+	errs := map[int]error{}
+
+	_, _ = NoRaceWith(ctx, []Option[int]{
+		WithTiming[int](func(index int, d time.Duration, err error) {
+			durations[index] = d
+			errs[index] = err
+		}),
+	},
+		func(ctx context.Context) (int, error) { return 0, nil },
+		func(ctx context.Context) (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 0, errors.New("slow and broken")
+		},
+	)
+
+	if len(durations) != 2 {
+		t.Fatalf("expected 2 timings, got %d", len(durations))
+	}
+	if durations[1] < 50*time.Millisecond {
+		t.Errorf("expected worker 1 to take >= 50ms, got %v", durations[1])
+	}
+	if durations[0] >= durations[1] {
+		t.Errorf("expected worker 0 to be faster, got %v vs %v", durations[0], durations[1])
+	}
+	if errs[0] != nil || errs[1] == nil {
+		t.Errorf("expected only worker 1 to report an error, got %v", errs)
+	}
+}
+
+func TestWithReturnOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	fast := func(ctx context.Context) (int, error) { return 1, nil }
+	stubborn := func(ctx context.Context) (int, error) {
+		time.Sleep(300 * time.Millisecond) // Ignores cancellation
+		return 2, nil
+	}
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	results, err := NoRaceWith(ctx, []Option[int]{WithReturnOnCancel[int]()}, fast, stubborn)
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("expected early return, took %v", elapsed)
+	}
+	if !errors.Is(err, ErrCancelled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected ErrCancelled wrapping context.Canceled, got %v", err)
+	}
+	if results[0].Value != 1 || results[0].Err != nil {
+		t.Errorf("expected worker 0 to keep its result, got %v", results[0])
+	}
+	if results[1].Err != ErrUnfinished || !results[1].Started {
+		t.Errorf("expected worker 1 to be unfinished but started, got %v", results[1])
+	}
+}
+
+// intervalLimiter lets one caller through per interval, like a rate.Limiter with a burst of 1.
+type intervalLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *intervalLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	select {
+	case <-time.After(time.Until(at)):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	t.Run("paces_workers", func(t *testing.T) {
+		ctx := context.Background()
+		limiter := &intervalLimiter{interval: 20 * time.Millisecond}
+		w := func(ctx context.Context) (int, error) { return 1, nil }
+
+		start := time.Now()
+		_, err := NoRaceWith(ctx, []Option[int]{WithRateLimit[int](limiter)}, w, w, w, w)
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+			t.Errorf("expected 4 workers to take >= 60ms at 1 per 20ms, took %v", elapsed)
+		}
+	})
+
+	t.Run("cancelled_while_waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		limiter := &intervalLimiter{interval: time.Second}
+		w := func(ctx context.Context) (int, error) { return 1, nil }
+
+		results, err := NoRaceWith(ctx, []Option[int]{WithRateLimit[int](limiter)}, w, w)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		waited := 0
+		for _, r := range results {
+			if errors.Is(r.Err, ErrRateLimitWait) {
+				waited++
+				if !errors.Is(r.Err, context.DeadlineExceeded) {
+					t.Errorf("expected the wait error to wrap DeadlineExceeded, got %v", r.Err)
+				}
+			}
+		}
+		if waited != 1 {
+			t.Errorf("expected 1 worker cancelled while waiting, got %d", waited)
+		}
+	})
+}
+
+func TestWithLogger(t *testing.T) {
+	t.Run("noRace_events", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		ctx := context.Background()
+		_, _ = NoRaceWith(ctx, []Option[int]{
+			WithLogger[int](logger),
+			WithNames[int]("ok", "broken"),
+		},
+			func(ctx context.Context) (int, error) { return 1, nil },
+			func(ctx context.Context) (int, error) { return 0, errors.New("boom") },
+		)
+
+		out := buf.String()
+		for _, want := range []string{
+			`msg="worker started" index=0 name=ok`,
+			`msg="worker finished" index=0 name=ok duration=`,
+			`msg="worker failed" index=1 name=broken duration=`,
+			`error=boom`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected log output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("race_events", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		ctx := context.Background()
+		_, err := RaceWith(ctx, []Option[int]{WithLogger[int](logger)},
+			func(ctx context.Context) (int, error) { return 1, nil },
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if !strings.Contains(buf.String(), `msg="worker finished" index=0`) {
+			t.Errorf("expected a finish event, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("correlation_id", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		ctx := WithCorrelationID(context.Background(), "req-42")
+		_, _ = NoRaceWith(ctx, []Option[int]{WithLogger[int](logger)},
+			func(ctx context.Context) (int, error) { return 1, nil },
+		)
+		if n := strings.Count(buf.String(), "correlation_id=req-42"); n != 2 {
+			t.Errorf("expected the ID on both events, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("nil_logger", func(t *testing.T) {
+		ctx := context.Background()
+		_, err := NoRaceWith(ctx, []Option[int]{WithLogger[int](nil)},
+			func(ctx context.Context) (int, error) { return 1, nil },
+		)
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+	})
+}
+
+func TestWithSoftTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	fast := func(ctx context.Context) (int, error) { return 10, nil }
+	aggregating := func(ctx context.Context) (int, error) {
+		sum := 0
+		for {
+			select {
+			case <-ctx.Done():
+				time.Sleep(5 * time.Millisecond) // Wrap up
+				return sum, nil
+			case <-time.After(time.Millisecond):
+				sum++
+			}
+		}
+	}
+	stuck := func(ctx context.Context) (int, error) {
+		time.Sleep(300 * time.Millisecond)
+		return 0, nil
+	}
+
+	start := time.Now()
+	results, err := NoRaceWith(ctx, []Option[int]{WithSoftTimeout[int](30*time.Millisecond, 40*time.Millisecond)},
+		fast, aggregating, stuck)
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("expected the stuck worker to be abandoned, took %v", elapsed)
+	}
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if results[0].Partial || results[0].Value != 10 {
+		t.Errorf("expected worker 0 to complete normally, got %+v", results[0])
+	}
+	if !results[1].Partial || results[1].Err != nil || results[1].Value == 0 {
+		t.Errorf("expected worker 1 to return a partial value, got %+v", results[1])
+	}
+	if results[2].Partial || !errors.Is(results[2].Err, ErrAbandoned) {
+		t.Errorf("expected worker 2 to be abandoned, got %+v", results[2])
+	}
+}
+
+func TestWithEscalatingTimeout(t *testing.T) {
+	cooperative := func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 1, ctx.Err()
+	}
+	release := make(chan struct{})
+	defer close(release)
+	uncooperative := func(ctx context.Context) (int, error) {
+		<-release
+		return 2, nil
+	}
+
+	start := time.Now()
+	opts := []Option[int]{WithEscalatingTimeout[int](20*time.Millisecond, 50*time.Millisecond)}
+	results, stats, _ := NoRaceWithStats(context.Background(), opts, cooperative, uncooperative)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > 200*time.Millisecond {
+		t.Errorf("expected to give up at the hard timeout, took %v", elapsed)
+	}
+
+	if !results[0].Partial || !errors.Is(results[0].Err, context.DeadlineExceeded) {
+		t.Errorf("expected worker 0 to return after the soft timeout, got %+v", results[0])
+	}
+	if !errors.Is(results[1].Err, ErrAbandoned) {
+		t.Errorf("expected worker 1 to be abandoned, got %+v", results[1])
+	}
+	if stats.Leaked != 1 || stats.Failed != 2 {
+		t.Errorf("expected 1 leaked of 2 failed workers, got %+v", stats)
+	}
+}
+
+func TestWithDynamicTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	sleepy := func(ctx context.Context) (int, error) {
+		select {
+		case <-time.After(50 * time.Millisecond):
+			return 1, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	timeouts := []time.Duration{10 * time.Millisecond, time.Second, 0}
+
+	results, err := NoRaceWith(ctx, []Option[int]{WithDynamicTimeout[int](func(index int) time.Duration {
+		return timeouts[index]
+	})}, sleepy, sleepy, sleepy)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if !errors.Is(results[0].Err, context.DeadlineExceeded) {
+		t.Errorf("expected worker 0 to time out, got %v", results[0].Err)
+	}
+	for _, i := range []int{1, 2} {
+		if results[i].Err != nil || results[i].Value != 1 {
+			t.Errorf("expected worker %d to complete, got %+v", i, results[i])
+		}
+	}
+}
+
+func TestWithPanicHandler(t *testing.T) {
+	t.Run("converts", func(t *testing.T) {
+		ctx := context.Background()
+		errFatal := errors.New("converted")
+		var gotIndex int32 = -1
+
+		handler := func(recovered any, index int) error {
+			atomic.StoreInt32(&gotIndex, int32(index))
+			return fmt.Errorf("%w: %v", errFatal, recovered)
+		}
+		ok := func(ctx context.Context) (int, error) { return 1, nil }
+		boom := func(ctx context.Context) (int, error) { panic("boom") }
+
+		results, err := NoRaceWith(ctx, []Option[int]{WithPanicHandler[int](handler)}, ok, boom)
+		if !errors.Is(err, errFatal) {
+			t.Fatalf("expected handler error, got %v", err)
+		}
+		var pe *PanicError
+		if errors.As(results[1].Err, &pe) {
+			t.Errorf("expected no *PanicError, got %v", results[1].Err)
+		}
+		if i := atomic.LoadInt32(&gotIndex); i != 1 {
+			t.Errorf("expected handler to see index 1, got %d", i)
+		}
+	})
+
+	t.Run("rethrows", func(t *testing.T) {
+		o := newOptions([]Option[int]{WithPanicHandler[int](func(any, int) error { return nil })})
+
+		defer func() {
+			if r := recover(); r != "fatal" {
+				t.Errorf("expected panic to be rethrown, recovered %v", r)
+			}
+		}()
+		o.call(context.Background(), 0, func(ctx context.Context) (int, error) { panic("fatal") })
+		t.Error("expected call to panic")
+	})
+}
+
+func TestWithStartStagger(t *testing.T) {
+	t.Run("spreads_starts", func(t *testing.T) {
+		ctx := context.Background()
+		start := time.Now()
+
+		w := func(ctx context.Context) (time.Duration, error) { return time.Since(start), nil }
+		results, err := NoRaceWith(ctx, []Option[time.Duration]{WithStartStagger[time.Duration](20 * time.Millisecond)}, w, w, w)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for i, res := range results {
+			if want := time.Duration(i) * 20 * time.Millisecond; res.Value < want {
+				t.Errorf("expected worker %d to start after %v, started after %v", i, want, res.Value)
+			}
+		}
+	})
+
+	t.Run("cancelled_while_waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		var ran int32
+
+		w := func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&ran, 1)
+			return 1, nil
+		}
+		start := time.Now()
+		results, _ := NoRaceWith(ctx, []Option[int]{WithStartStagger[int](time.Second)}, w, w, w)
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("expected the stagger to be interrupted, took %v", elapsed)
+		}
+		if r := atomic.LoadInt32(&ran); r != 1 {
+			t.Errorf("expected only worker 0 to run, got %d", r)
+		}
+		if !errors.Is(results[2].Err, context.DeadlineExceeded) {
+			t.Errorf("expected worker 2 to report the deadline, got %v", results[2].Err)
+		}
+	})
+}
+
+func TestWithErrorContext(t *testing.T) {
+	ctx := context.Background()
+	errRefused := errors.New("connection refused")
+	ok := func(ctx context.Context) (int, error) { return 1, nil }
+	fail := func(ctx context.Context) (int, error) { return 0, errRefused }
+
+	opts := []Option[int]{WithErrorContext[int](), WithNames[int]("", "fetch-user")}
+	results, err := NoRaceWith(ctx, opts, ok, fail, fail)
+	if !errors.Is(err, errRefused) {
+		t.Fatalf("expected the original error in the chain, got %v", err)
+	}
+	if results[0].Err != nil {
+		t.Errorf("expected worker 0 to succeed, got %v", results[0].Err)
+	}
+	if got, want := results[1].Err.Error(), `worker "fetch-user" (index 1): connection refused`; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, want := results[2].Err.Error(), "worker (index 2): connection refused"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if !errors.Is(results[1].Err, errRefused) {
+		t.Error("expected the wrapped error to unwrap to the original")
+	}
+
+	merr := err.(*MultiError[int])
+	want := "multiple errors occurred:\n - worker \"fetch-user\" (index 1): connection refused\n - worker (index 2): connection refused"
+	if got := merr.Error(); got != want {
+		t.Errorf("expected the worker to be labelled once, got %q", got)
+	}
+	want = `2 errors: worker "fetch-user" (index 1): connection refused; worker (index 2): connection refused`
+	if got := merr.Compact(); got != want {
+		t.Errorf("expected the worker to be labelled once, got %q", got)
+	}
+}
+
+func TestWithResultBuffer(t *testing.T) {
+	ctx := context.Background()
+	w := func(ctx context.Context) (int, error) { return 1, nil }
+	buf := make([]Result[int], 4)
+	buf[1] = Result[int]{Value: 99, Err: errors.New("stale")}
+
+	results, err := NoRaceWith(ctx, []Option[int]{WithResultBuffer(buf)}, w, w)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 2 || &results[0] != &buf[0] {
+		t.Fatal("expected the results to reuse the buffer")
+	}
+	if results[1].Value != 1 || results[1].Err != nil {
+		t.Errorf("expected stale entries to be overwritten, got %+v", results[1])
+	}
+
+	results, _ = NoRaceWith(ctx, []Option[int]{WithResultBuffer(buf[:0:1])}, w, w)
+	if &results[0] == &buf[0] {
+		t.Error("expected a new slice when the buffer is too small")
+	}
+
+	opts := []Option[int]{WithResultBuffer(buf)}
+	allocs := testing.AllocsPerRun(100, func() {
+		NoRaceWith(ctx, opts, w, w)
+	})
+	baseline := testing.AllocsPerRun(100, func() {
+		NoRaceWith(ctx, nil, w, w)
+	})
+	if allocs >= baseline {
+		t.Errorf("expected fewer allocations with a buffer, got %v vs %v", allocs, baseline)
+	}
+}
+
+func TestWithSignalCancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sending os.Interrupt is not supported on Windows")
+	}
+	self, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal("cannot find the test process:", err)
+	}
+
+	ctx := context.Background()
+	started := make(chan struct{})
+	ok := func(ctx context.Context) (int, error) { return 1, nil }
+	block := func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+
+	go func() {
+		<-started
+		self.Signal(os.Interrupt)
+	}()
+
+	results, err := NoRaceWith(ctx, []Option[int]{WithSignalCancel[int](os.Interrupt)}, ok, block)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the signal to cancel the call, got %v", err)
+	}
+	if results[0].Value != 1 || results[0].Err != nil {
+		t.Errorf("expected the finished worker to be kept, got %+v", results[0])
+	}
+}
+
+func TestWithTransform(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+	token := func(ctx context.Context) (string, error) { return "secret-token", nil }
+	fail := func(ctx context.Context) (string, error) { return "partial-secret", errBoom }
+
+	var seen []string
+	var mu sync.Mutex
+	opts := []Option[string]{
+		WithTransform(func(s string) string { return strings.Repeat("*", len(s)) }),
+		WithOnResult(func(res Result[string]) {
+			mu.Lock()
+			seen = append(seen, res.Value)
+			mu.Unlock()
+		}),
+	}
+	results, _ := NoRaceWith(ctx, opts, token, fail)
+	if results[0].Value != "************" {
+		t.Errorf("expected a redacted value, got %q", results[0].Value)
+	}
+	if results[1].Value != "partial-secret" || results[1].Err != errBoom {
+		t.Errorf("expected the failure untouched, got %+v", results[1])
+	}
+	if slices.Contains(seen, "secret-token") {
+		t.Error("expected hooks to see only the transformed value")
+	}
+}
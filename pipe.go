@@ -0,0 +1,85 @@
+package gocrc
+
+import (
+	"context"
+	"io"
+	"runtime/debug"
+)
+
+// StreamWorker transforms a byte stream. It returns a reader of its output, typically one that
+// processes r lazily as it is read. If the returned reader is also an io.Closer, it is closed once
+// fully consumed or abandoned.
+type StreamWorker func(ctx context.Context, r io.Reader) (io.Reader, error)
+
+// Pipe connects src through the stages with io.Pipe, each stage running in its own goroutine, so that
+// all stages transform data concurrently and each applies backpressure to the one before it.
+// The returned reader yields the output of the last stage. An error in any stage, or the context being
+// done, closes the pipes so that reads of the output fail with that error; a panicking stage fails
+// with a *PanicError. The caller must close the returned reader, which also stops every stage if
+// done early.
+func Pipe(ctx context.Context, src io.Reader, stages ...StreamWorker) io.ReadCloser {
+	if len(stages) == 0 {
+		return io.NopCloser(src)
+	}
+
+	in := src
+	var writers []*io.PipeWriter
+	var readers []*io.PipeReader
+	for _, stage := range stages {
+		pr, pw := io.Pipe()
+		writers = append(writers, pw)
+		readers = append(readers, pr)
+		go runStreamStage(ctx, stage, in, pw)
+		in = pr
+	}
+	// Closing the writers makes every reader, the output included, fail with the context error;
+	// closing the inner readers makes the stages blocked on writing fail with it too.
+	stop := context.AfterFunc(ctx, func() {
+		for _, pw := range writers {
+			pw.CloseWithError(ctx.Err())
+		}
+		for _, pr := range readers[:len(readers)-1] {
+			pr.CloseWithError(ctx.Err())
+		}
+	})
+	return &pipeOutput{PipeReader: readers[len(readers)-1], stop: stop}
+}
+
+// runStreamStage feeds the output of stage into pw. Once done it closes pw, passing on any error
+// downstream, and closes its input if that is a pipe, so that the previous stage stops too.
+func runStreamStage(ctx context.Context, stage StreamWorker, in io.Reader, pw *io.PipeWriter) {
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{Value: r, Stack: debug.Stack()}
+			}
+		}()
+		out, err := stage(ctx, in)
+		if err != nil {
+			return err
+		}
+		if c, ok := out.(io.Closer); ok {
+			defer c.Close()
+		}
+		_, err = io.Copy(pw, out)
+		return err
+	}()
+
+	pw.CloseWithError(err)
+	if pr, ok := in.(*io.PipeReader); ok {
+		if err == nil {
+			err = io.ErrClosedPipe
+		}
+		pr.CloseWithError(err)
+	}
+}
+
+type pipeOutput struct {
+	*io.PipeReader
+	stop func() bool
+}
+
+func (p *pipeOutput) Close() error {
+	p.stop()
+	return p.PipeReader.Close()
+}
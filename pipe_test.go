@@ -0,0 +1,94 @@
+package gocrc
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mapLines returns a StreamWorker applying fn to every line of its input.
+func mapLines(fn func(string) (string, error)) StreamWorker {
+	return func(ctx context.Context, r io.Reader) (io.Reader, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			sc := bufio.NewScanner(r)
+			for sc.Scan() {
+				line, err := fn(sc.Text())
+				if err != nil {
+					pw.CloseWithError(err)
+					return
+				}
+				if _, err := io.WriteString(pw, line+"\n"); err != nil {
+					return
+				}
+			}
+			pw.CloseWithError(sc.Err())
+		}()
+		return pr, nil
+	}
+}
+
+func TestPipe(t *testing.T) {
+	upper := mapLines(func(s string) (string, error) { return strings.ToUpper(s), nil })
+	exclaim := mapLines(func(s string) (string, error) { return s + "!", nil })
+
+	t.Run("chains_stages", func(t *testing.T) {
+		out := Pipe(context.Background(), strings.NewReader("a\nb\n"), upper, exclaim)
+		defer out.Close()
+
+		got, err := io.ReadAll(out)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if string(got) != "A!\nB!\n" {
+			t.Errorf("expected %q, got %q", "A!\nB!\n", got)
+		}
+	})
+
+	t.Run("stage_error", func(t *testing.T) {
+		errBad := errors.New("bad line")
+		reject := mapLines(func(s string) (string, error) {
+			if s == "B" {
+				return "", errBad
+			}
+			return s, nil
+		})
+
+		out := Pipe(context.Background(), strings.NewReader("a\nb\nc\n"), upper, reject, exclaim)
+		defer out.Close()
+
+		if _, err := io.ReadAll(out); !errors.Is(err, errBad) {
+			t.Errorf("expected the stage error, got %v", err)
+		}
+	})
+
+	t.Run("stage_fails_to_start", func(t *testing.T) {
+		errSetup := errors.New("setup failed")
+		broken := func(ctx context.Context, r io.Reader) (io.Reader, error) { return nil, errSetup }
+
+		out := Pipe(context.Background(), strings.NewReader("a\n"), upper, broken)
+		defer out.Close()
+
+		if _, err := io.ReadAll(out); !errors.Is(err, errSetup) {
+			t.Errorf("expected the setup error, got %v", err)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		endless, w := io.Pipe()
+		defer w.Close()
+
+		out := Pipe(ctx, endless, upper)
+		defer out.Close()
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		if _, err := io.ReadAll(out); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
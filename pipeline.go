@@ -0,0 +1,116 @@
+package gocrc
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Pipeline passes items through a sequence of stages. Each stage processes several items
+// concurrently, and items flow from one stage to the next through bounded channels, so a slow
+// stage applies backpressure to the ones before it.
+type Pipeline[T any] struct {
+	stages []pipelineStage[T]
+}
+
+type pipelineStage[T any] struct {
+	limit int
+	fn    func(context.Context, T) (T, error)
+}
+
+type pipelineItem[T any] struct {
+	index int
+	val   T
+	err   error
+	// startedAt and finishedAt span the stages that ran on the item; startedAt is zero if none did.
+	startedAt  time.Time
+	finishedAt time.Time
+}
+
+// NewPipeline returns an empty pipeline.
+func NewPipeline[T any]() *Pipeline[T] {
+	return &Pipeline[T]{}
+}
+
+// Stage appends a stage processing up to runtime.GOMAXPROCS(0) items at a time.
+func (p *Pipeline[T]) Stage(fn func(context.Context, T) (T, error)) *Pipeline[T] {
+	return p.StageN(runtime.GOMAXPROCS(0), fn)
+}
+
+// StageN appends a stage processing up to limit items at a time. A limit below 1 is treated as 1.
+func (p *Pipeline[T]) StageN(limit int, fn func(context.Context, T) (T, error)) *Pipeline[T] {
+	p.stages = append(p.stages, pipelineStage[T]{limit: max(limit, 1), fn: fn})
+	return p
+}
+
+// Run feeds the inputs through every stage and returns one Result per input, in input order.
+// An error short-circuits only the affected item: it skips the remaining stages and is reported
+// in its Result and in the returned MultiError, while other items carry on.
+// Once the context is done, items not yet processed carry ctx.Err().
+func (p *Pipeline[T]) Run(ctx context.Context, inputs []T) ([]Result[T], error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	src := make(chan pipelineItem[T])
+	go func() {
+		defer close(src)
+		for i, v := range inputs {
+			src <- pipelineItem[T]{index: i, val: v}
+		}
+	}()
+
+	var in <-chan pipelineItem[T] = src
+	for _, st := range p.stages {
+		in = st.run(ctx, in)
+	}
+
+	results := make([]Result[T], len(inputs))
+	for it := range in {
+		results[it.index] = Result[T]{
+			Value:      it.val,
+			Err:        it.err,
+			Index:      it.index,
+			StartedAt:  it.startedAt,
+			FinishedAt: it.finishedAt,
+			Started:    !it.startedAt.IsZero(),
+		}
+	}
+	return results, collectErrors(results)
+}
+
+func (st pipelineStage[T]) run(ctx context.Context, in <-chan pipelineItem[T]) <-chan pipelineItem[T] {
+	out := make(chan pipelineItem[T], st.limit)
+
+	var wg sync.WaitGroup
+	wg.Add(st.limit)
+	for range st.limit {
+		go func() {
+			defer wg.Done()
+			for it := range in {
+				if it.err == nil {
+					if err := ctx.Err(); err != nil {
+						it.err = err
+					} else {
+						v := it.val
+						if it.startedAt.IsZero() {
+							it.startedAt = time.Now()
+						}
+						it.val, it.err = call(withWorker(ctx, it.index, ""), func(ctx context.Context) (T, error) {
+							return st.fn(ctx, v)
+						})
+						it.finishedAt = time.Now()
+					}
+				}
+				out <- it
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
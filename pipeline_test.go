@@ -0,0 +1,95 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPipeline(t *testing.T) {
+	t.Run("stages_in_order", func(t *testing.T) {
+		ctx := context.Background()
+		p := NewPipeline[string]().
+			Stage(func(ctx context.Context, s string) (string, error) {
+				return strings.TrimSpace(s), nil
+			}).
+			StageN(2, func(ctx context.Context, s string) (string, error) {
+				return strings.ToUpper(s), nil
+			}).
+			Stage(func(ctx context.Context, s string) (string, error) {
+				return s + "!", nil
+			})
+
+		results, err := p.Run(ctx, []string{" a ", "b ", " c"})
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		want := []string{"A!", "B!", "C!"}
+		for i, r := range results {
+			if r.Value != want[i] || r.Index != i {
+				t.Errorf("result %d: expected %q, got %v", i, want[i], r)
+			}
+			if !r.Started || r.StartedAt.IsZero() || r.FinishedAt.Before(r.StartedAt) {
+				t.Errorf("result %d: expected start and finish times, got %+v", i, r)
+			}
+		}
+	})
+
+	t.Run("error_short_circuits_item", func(t *testing.T) {
+		ctx := context.Background()
+		var secondStage int32
+		p := NewPipeline[int]().
+			Stage(func(ctx context.Context, n int) (int, error) {
+				if n < 0 {
+					return 0, errors.New("negative")
+				}
+				return n * 2, nil
+			}).
+			Stage(func(ctx context.Context, n int) (int, error) {
+				atomic.AddInt32(&secondStage, 1)
+				return n + 1, nil
+			})
+
+		results, err := p.Run(ctx, []int{1, -1, 3})
+		merr, ok := err.(*MultiError[int])
+		if !ok {
+			t.Fatalf("expected *MultiError[int], got %T", err)
+		}
+		if len(merr.Results) != 1 || merr.Results[0].Index != 1 {
+			t.Errorf("expected item 1 to fail, got %v", merr.Results)
+		}
+		if results[0].Value != 3 || results[2].Value != 7 {
+			t.Errorf("expected other items to finish, got %v", results)
+		}
+		if n := atomic.LoadInt32(&secondStage); n != 2 {
+			t.Errorf("expected the failed item to skip stage 2, got %d calls", n)
+		}
+	})
+
+	t.Run("bounded_parallelism", func(t *testing.T) {
+		ctx := context.Background()
+		var inFlight, peak int32
+		p := NewPipeline[int]().StageN(2, func(ctx context.Context, n int) (int, error) {
+			cur := atomic.AddInt32(&inFlight, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return n, nil
+		})
+
+		if _, err := p.Run(ctx, []int{1, 2, 3, 4, 5, 6}); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if pk := atomic.LoadInt32(&peak); pk > 2 {
+			t.Errorf("expected at most 2 in flight, got %d", pk)
+		}
+	})
+}
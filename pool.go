@@ -0,0 +1,80 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPoolClosed is reported by Pool.Submit once the pool has been closed.
+var ErrPoolClosed = errors.New("gocrc: pool is closed")
+
+// Pool keeps a fixed set of goroutines alive and reuses them to run submitted workers.
+// It is intended for long-lived services where spawning goroutines per batch is undesirable.
+type Pool[T any] struct {
+	tasks  chan poolTask[T]
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+	next   atomic.Int64
+}
+
+type poolTask[T any] struct {
+	worker Worker[T]
+	index  int
+	out    chan Result[T]
+}
+
+// NewPool starts a pool with size goroutines. A size below 1 is treated as 1.
+func NewPool[T any](size int) *Pool[T] {
+	if size < 1 {
+		size = 1
+	}
+
+	p := &Pool[T]{tasks: make(chan poolTask[T])}
+	p.wg.Add(size)
+	for range size {
+		go p.loop()
+	}
+	return p
+}
+
+func (p *Pool[T]) loop() {
+	defer p.wg.Done()
+	for t := range p.tasks {
+		t.out <- invoke(context.Background(), t.index, t.worker)
+	}
+}
+
+// Submit hands the worker to the next idle goroutine and returns a channel that receives its Result.
+// The Index of the Result is the submission sequence number. Submit blocks while every goroutine is busy.
+// After Close, the returned channel immediately yields a Result carrying ErrPoolClosed.
+func (p *Pool[T]) Submit(w Worker[T]) <-chan Result[T] {
+	out := make(chan Result[T], 1)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		out <- Result[T]{Index: IndexCancelled, Err: ErrPoolClosed}
+		return out
+	}
+
+	index := int(p.next.Add(1) - 1)
+	p.tasks <- poolTask[T]{worker: w, index: index, out: out}
+	return out
+}
+
+// Close stops accepting new workers and waits for the in-flight ones to finish.
+// It is safe to call Close more than once.
+func (p *Pool[T]) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
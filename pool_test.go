@@ -0,0 +1,66 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	t.Run("reuses_goroutines", func(t *testing.T) {
+		p := NewPool[int](2)
+		defer p.Close()
+
+		var chans []<-chan Result[int]
+		for i := range 5 {
+			chans = append(chans, p.Submit(func(ctx context.Context) (int, error) {
+				return i * 10, nil
+			}))
+		}
+
+		for i, ch := range chans {
+			res := <-ch
+			if res.Err != nil {
+				t.Errorf("expected nil error, got %v", res.Err)
+			}
+			if res.Value != i*10 {
+				t.Errorf("expected %d, got %d", i*10, res.Value)
+			}
+			if res.Index != i {
+				t.Errorf("expected index %d, got %d", i, res.Index)
+			}
+		}
+	})
+
+	t.Run("close_waits_for_in_flight", func(t *testing.T) {
+		p := NewPool[string](1)
+		var done int32
+
+		ch := p.Submit(func(ctx context.Context) (string, error) {
+			time.Sleep(50 * time.Millisecond)
+			atomic.StoreInt32(&done, 1)
+			return "late", nil
+		})
+
+		p.Close()
+		if atomic.LoadInt32(&done) != 1 {
+			t.Errorf("expected Close to wait for the in-flight worker")
+		}
+		if res := <-ch; res.Value != "late" {
+			t.Errorf("expected 'late', got %v", res.Value)
+		}
+	})
+
+	t.Run("submit_after_close", func(t *testing.T) {
+		p := NewPool[int](1)
+		p.Close()
+		p.Close()
+
+		res := <-p.Submit(func(ctx context.Context) (int, error) { return 1, nil })
+		if !errors.Is(res.Err, ErrPoolClosed) {
+			t.Errorf("expected ErrPoolClosed, got %v", res.Err)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package gocrc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// QuorumError is returned by RaceQuorum when every worker completed but no value reached the quorum.
+type QuorumError[T comparable] struct {
+	// Quorum is the number of identical values that was required.
+	Quorum int
+	// Tally counts how many workers returned each successful value.
+	Tally map[T]int
+	// Failures holds the failed workers, in index order.
+	Failures []Result[T]
+}
+
+func (e *QuorumError[T]) Error() string {
+	return fmt.Sprintf("no value reached a quorum of %d: tally %v, %d failed", e.Quorum, e.Tally, len(e.Failures))
+}
+
+// Unwrap returns the errors of the failed workers.
+func (e *QuorumError[T]) Unwrap() []error {
+	return AllErrors(e.Failures)
+}
+
+// RaceQuorum runs multiple workers concurrently and returns as soon as quorum of them have
+// successfully returned the same value, cancelling the rest. If every worker completes without any
+// value reaching the quorum, a *QuorumError describing the tally is returned.
+func RaceQuorum[T comparable](ctx context.Context, quorum int, workers ...Worker[T]) (T, error) {
+	var zero T
+	if quorum < 1 {
+		quorum = 1
+	}
+
+	quorumCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	resultCh := spawn(quorumCtx, workers)
+	tally := make(map[T]int)
+	var failures []Result[T]
+	for range workers {
+		select {
+		case res := <-resultCh:
+			if res.Err != nil {
+				failures = append(failures, res)
+				continue
+			}
+			tally[res.Value]++
+			if tally[res.Value] >= quorum {
+				cancel(ErrRaceLost)
+				return res.Value, nil
+			}
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+
+	slices.SortFunc(failures, func(a, b Result[T]) int { return a.Index - b.Index })
+	return zero, &QuorumError[T]{Quorum: quorum, Tally: tally, Failures: failures}
+}
@@ -0,0 +1,61 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRaceQuorum(t *testing.T) {
+	replica := func(d time.Duration, v string, err error) Worker[string] {
+		return func(ctx context.Context) (string, error) {
+			select {
+			case <-time.After(d):
+				return v, err
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+	}
+
+	t.Run("majority_wins", func(t *testing.T) {
+		ctx := context.Background()
+		v, err := RaceQuorum(ctx, 2,
+			replica(10*time.Millisecond, "stale", nil),
+			replica(20*time.Millisecond, "fresh", nil),
+			replica(30*time.Millisecond, "fresh", nil),
+			replica(time.Second, "fresh", nil),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if v != "fresh" {
+			t.Errorf("expected 'fresh', got %q", v)
+		}
+	})
+
+	t.Run("no_quorum", func(t *testing.T) {
+		ctx := context.Background()
+		down := errors.New("down")
+		_, err := RaceQuorum(ctx, 2,
+			replica(0, "a", nil),
+			replica(0, "b", nil),
+			replica(0, "", down),
+		)
+
+		var qerr *QuorumError[string]
+		if !errors.As(err, &qerr) {
+			t.Fatalf("expected *QuorumError[string], got %T", err)
+		}
+		if qerr.Tally["a"] != 1 || qerr.Tally["b"] != 1 || len(qerr.Failures) != 1 {
+			t.Errorf("unexpected tally: %v, failures %v", qerr.Tally, qerr.Failures)
+		}
+		if !errors.Is(err, down) {
+			t.Errorf("expected the failure to be unwrappable")
+		}
+		if want := "no value reached a quorum of 2: tally map[a:1 b:1], 1 failed"; err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+	})
+}
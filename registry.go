@@ -0,0 +1,78 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrGroupCancelled is the cancellation cause, as reported by context.Cause, seen by the workers of
+// a call cancelled through Registry.Cancel.
+var ErrGroupCancelled = errors.New("gocrc: group cancelled via registry")
+
+// Registry tracks running calls by key so that they can be cancelled from elsewhere, e.g. by request
+// ID from an HTTP handler. Calls join it with WithGroupKey and leave it when they return.
+// The zero value is ready to use.
+type Registry struct {
+	mu     sync.Mutex
+	groups map[string]map[*registration]struct{}
+}
+
+type registration struct {
+	cancel context.CancelCauseFunc
+}
+
+// Cancel cancels every running call registered under key and reports whether there was any.
+func (r *Registry) Cancel(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	regs := r.groups[key]
+	for reg := range regs {
+		reg.cancel(ErrGroupCancelled)
+	}
+	return len(regs) > 0
+}
+
+// Running returns the number of calls currently registered under key.
+func (r *Registry) Running(key string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.groups[key])
+}
+
+// register derives a context that Cancel(key) cancels. The returned release function deregisters it
+// and must be called once the call is over.
+func (r *Registry) register(ctx context.Context, key string) (context.Context, func()) {
+	groupCtx, cancel := context.WithCancelCause(ctx)
+	reg := &registration{cancel: cancel}
+
+	r.mu.Lock()
+	if r.groups == nil {
+		r.groups = make(map[string]map[*registration]struct{})
+	}
+	if r.groups[key] == nil {
+		r.groups[key] = make(map[*registration]struct{})
+	}
+	r.groups[key][reg] = struct{}{}
+	r.mu.Unlock()
+
+	return groupCtx, func() {
+		r.mu.Lock()
+		delete(r.groups[key], reg)
+		if len(r.groups[key]) == 0 {
+			delete(r.groups, key)
+		}
+		r.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// WithGroupKey registers the call under key in registry for as long as it runs, so that
+// registry.Cancel(key) cancels the context of all its workers. Several calls may share a key.
+// It applies to NoRaceWith and RaceWith.
+func WithGroupKey[T any](registry *Registry, key string) Option[T] {
+	return func(o *options[T]) {
+		o.registry = registry
+		o.groupKey = key
+	}
+}
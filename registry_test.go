@@ -0,0 +1,72 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	var registry Registry
+	ctx := context.Background()
+
+	started := make(chan struct{}, 2)
+	block := func(ctx context.Context) (int, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return 0, context.Cause(ctx)
+	}
+
+	type outcome struct {
+		results []Result[int]
+		err     error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		results, err := NoRaceWith(ctx, []Option[int]{WithGroupKey[int](&registry, "import-42")}, block, block)
+		done <- outcome{results, err}
+	}()
+	<-started
+	<-started
+
+	if n := registry.Running("import-42"); n != 1 {
+		t.Fatalf("expected 1 running call, got %d", n)
+	}
+	if registry.Cancel("import-7") {
+		t.Error("expected Cancel to report false for an unknown key")
+	}
+	if !registry.Cancel("import-42") {
+		t.Error("expected Cancel to report true")
+	}
+
+	select {
+	case out := <-done:
+		for _, res := range out.results {
+			if !errors.Is(res.Err, ErrGroupCancelled) {
+				t.Errorf("expected ErrGroupCancelled as the cause, got %v", res.Err)
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the batch to be cancelled")
+	}
+
+	if n := registry.Running("import-42"); n != 0 {
+		t.Errorf("expected the call to deregister, got %d running", n)
+	}
+}
+
+func TestRegistryRace(t *testing.T) {
+	var registry Registry
+	w := func(ctx context.Context) (int, error) { return 1, nil }
+
+	if _, err := RaceWith(context.Background(), []Option[int]{WithGroupKey[int](&registry, "r")}, w); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := registry.Running("r"); n != 0 {
+		t.Errorf("expected the call to deregister, got %d running", n)
+	}
+	if len(registry.groups) != 0 {
+		t.Errorf("expected no keys left, got %v", registry.groups)
+	}
+}
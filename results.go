@@ -0,0 +1,130 @@
+package gocrc
+
+import (
+	"fmt"
+	"slices"
+)
+
+// FirstError returns the error of the first failed result in slice order, or nil if none failed.
+func FirstError[T any](results []Result[T]) error {
+	for _, r := range results {
+		if r.Err != nil {
+			return r.Err
+		}
+	}
+	return nil
+}
+
+// AllErrors returns the errors of the failed results in slice order, or nil if none failed.
+func AllErrors[T any](results []Result[T]) []error {
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs
+}
+
+// ResultMap keys the named results by Name for direct lookup. Unnamed results are left out.
+// If several results share a name, the last one in slice order wins.
+func ResultMap[T any](results []Result[T]) map[string]Result[T] {
+	m := make(map[string]Result[T], len(results))
+	for _, r := range results {
+		if r.Name != "" {
+			m[r.Name] = r
+		}
+	}
+	return m
+}
+
+// Partition splits results into those that succeeded and those that failed, keeping slice order in both.
+func Partition[T any](results []Result[T]) (successes, failures []Result[T]) {
+	for _, r := range results {
+		if r.Err != nil {
+			failures = append(failures, r)
+		} else {
+			successes = append(successes, r)
+		}
+	}
+	return successes, failures
+}
+
+// Pair holds the values of two results that share an index. See Zip.
+type Pair[A, B any] struct {
+	A A
+	B B
+}
+
+// Zip pairs the values of as and bs by position, e.g. the results of two NoRace batches run over
+// the same inputs. It fails if the lengths differ or if either result at some position failed;
+// the error names the first such position and wraps the failure.
+func Zip[A, B any](as []Result[A], bs []Result[B]) ([]Pair[A, B], error) {
+	if len(as) != len(bs) {
+		return nil, fmt.Errorf("gocrc: cannot zip %d results with %d", len(as), len(bs))
+	}
+
+	pairs := make([]Pair[A, B], len(as))
+	for i := range as {
+		if err := as[i].Err; err != nil {
+			return nil, fmt.Errorf("gocrc: zip index %d: first result failed: %w", i, err)
+		}
+		if err := bs[i].Err; err != nil {
+			return nil, fmt.Errorf("gocrc: zip index %d: second result failed: %w", i, err)
+		}
+		pairs[i] = Pair[A, B]{A: as[i].Value, B: bs[i].Value}
+	}
+	return pairs, nil
+}
+
+// SortByIndex sorts results in place by Index, e.g. to put Stream output back in input order.
+func SortByIndex[T any](results []Result[T]) {
+	slices.SortStableFunc(results, func(a, b Result[T]) int { return a.Index - b.Index })
+}
+
+// ResultsEqual reports whether a and b hold the same outcomes position by position: equal Index, Name,
+// Partial and Started, values equal according to eq, and errors that are both nil, identical or with the same
+// message. Timing fields (StartedAt, FinishedAt, CompletionOrder) are ignored, so that results of
+// separate runs can be compared in tests.
+func ResultsEqual[T any](a, b []Result[T], eq func(x, y T) bool) bool {
+	return slices.EqualFunc(a, b, func(x, y Result[T]) bool {
+		return x.Index == y.Index && x.Name == y.Name && x.Partial == y.Partial && x.Started == y.Started &&
+			errorsEqual(x.Err, y.Err) && eq(x.Value, y.Value)
+	})
+}
+
+func errorsEqual(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a == b || a.Error() == b.Error()
+}
+
+// MapResults converts results to Results of another type, applying fn to the Value of every successful
+// one. Failed results keep their error and get a zero Value; all other fields are copied.
+func MapResults[T, U any](results []Result[T], fn func(T) U) []Result[U] {
+	out := make([]Result[U], len(results))
+	for i, r := range results {
+		var val U
+		if r.Err == nil {
+			val = fn(r.Value)
+		}
+		out[i] = withValue(r, val)
+	}
+	return out
+}
+
+// withValue returns a copy of res holding val in place of its Value.
+func withValue[T, U any](res Result[T], val U) Result[U] {
+	return Result[U]{
+		Value:           val,
+		Err:             res.Err,
+		Index:           res.Index,
+		CompletionOrder: res.CompletionOrder,
+		Name:            res.Name,
+		StartedAt:       res.StartedAt,
+		FinishedAt:      res.FinishedAt,
+		Partial:         res.Partial,
+		Started:         res.Started,
+	}
+}
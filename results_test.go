@@ -0,0 +1,148 @@
+package gocrc
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFirstError(t *testing.T) {
+	err1 := errors.New("err1")
+	err3 := errors.New("err3")
+	results := []Result[int]{{Index: 0}, {Err: err1, Index: 1}, {Index: 2}, {Err: err3, Index: 3}}
+
+	if got := FirstError(results); got != err1 {
+		t.Errorf("expected %v, got %v", err1, got)
+	}
+	if got := FirstError[int](nil); got != nil {
+		t.Errorf("expected nil for a nil slice, got %v", got)
+	}
+	if got := FirstError(results[:1]); got != nil {
+		t.Errorf("expected nil without failures, got %v", got)
+	}
+}
+
+func TestAllErrors(t *testing.T) {
+	err1 := errors.New("err1")
+	err3 := errors.New("err3")
+	results := []Result[int]{{Index: 0}, {Err: err1, Index: 1}, {Index: 2}, {Err: err3, Index: 3}}
+
+	errs := AllErrors(results)
+	if len(errs) != 2 || errs[0] != err1 || errs[1] != err3 {
+		t.Errorf("expected [err1 err3], got %v", errs)
+	}
+	if errs := AllErrors([]Result[int]{}); errs != nil {
+		t.Errorf("expected nil for an empty slice, got %v", errs)
+	}
+}
+
+func TestResultMap(t *testing.T) {
+	results := []Result[int]{
+		{Value: 1, Index: 0, Name: "users"},
+		{Value: 2, Index: 1},
+		{Value: 3, Index: 2, Name: "orders"},
+		{Value: 4, Index: 3, Name: "users"},
+	}
+
+	m := ResultMap(results)
+	if len(m) != 2 {
+		t.Fatalf("expected 2 named results, got %d", len(m))
+	}
+	if m["orders"].Value != 3 {
+		t.Errorf("expected orders to map to 3, got %v", m["orders"])
+	}
+	if m["users"].Index != 3 {
+		t.Errorf("expected the last duplicate to win, got index %d", m["users"].Index)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	err1 := errors.New("err1")
+	err3 := errors.New("err3")
+	results := []Result[int]{{Index: 0}, {Err: err1, Index: 1}, {Index: 2}, {Err: err3, Index: 3}}
+
+	successes, failures := Partition(results)
+	if len(successes) != 2 || successes[0].Index != 0 || successes[1].Index != 2 {
+		t.Errorf("expected successes [0 2], got %v", successes)
+	}
+	if len(failures) != 2 || failures[0].Err != err1 || failures[1].Err != err3 {
+		t.Errorf("expected failures [1 3], got %v", failures)
+	}
+
+	if s, f := Partition[int](nil); s != nil || f != nil {
+		t.Errorf("expected nil slices for nil input, got %v, %v", s, f)
+	}
+}
+
+func TestZip(t *testing.T) {
+	as := []Result[int]{{Value: 1, Index: 0}, {Value: 2, Index: 1}}
+	bs := []Result[string]{{Value: "one", Index: 0}, {Value: "two", Index: 1}}
+
+	pairs, err := Zip(as, bs)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pairs) != 2 || pairs[0] != (Pair[int, string]{1, "one"}) || pairs[1] != (Pair[int, string]{2, "two"}) {
+		t.Errorf("unexpected pairs %v", pairs)
+	}
+
+	if _, err := Zip(as, bs[:1]); err == nil {
+		t.Error("expected an error for mismatched lengths")
+	}
+
+	errLookup := errors.New("lookup failed")
+	bs[1].Err = errLookup
+	_, err = Zip(as, bs)
+	if !errors.Is(err, errLookup) || !strings.Contains(err.Error(), "index 1") {
+		t.Errorf("expected the failure at index 1, got %v", err)
+	}
+}
+
+func TestSortByIndex(t *testing.T) {
+	results := []Result[int]{{Index: 2, Value: 20}, {Index: 0, Value: 0}, {Index: 1, Value: 10}}
+	SortByIndex(results)
+	for i, res := range results {
+		if res.Index != i || res.Value != i*10 {
+			t.Errorf("expected index %d at position %d, got %+v", i, i, res)
+		}
+	}
+}
+
+func TestResultsEqual(t *testing.T) {
+	eq := func(x, y int) bool { return x == y }
+	a := []Result[int]{{Index: 0, Value: 1}, {Index: 1, Err: errors.New("boom")}}
+	b := []Result[int]{
+		{Index: 0, Value: 1, CompletionOrder: 1, StartedAt: time.Now()},
+		{Index: 1, Err: errors.New("boom"), FinishedAt: time.Now()},
+	}
+
+	if !ResultsEqual(a, b, eq) {
+		t.Error("expected results differing only in timing to be equal")
+	}
+	b[1].Err = errors.New("other")
+	if ResultsEqual(a, b, eq) {
+		t.Error("expected different errors to compare unequal")
+	}
+	b[1].Err = nil
+	if ResultsEqual(a, b, eq) {
+		t.Error("expected a nil and a non-nil error to compare unequal")
+	}
+	if ResultsEqual(a, a[:1], eq) {
+		t.Error("expected different lengths to compare unequal")
+	}
+}
+
+func TestMapResults(t *testing.T) {
+	errBoom := errors.New("boom")
+	results := []Result[int]{{Value: 3, Index: 0, Name: "a", Started: true}, {Value: 9, Err: errBoom, Index: 1}}
+
+	mapped := MapResults(results, func(n int) string { return strconv.Itoa(n * 2) })
+	if mapped[0].Value != "6" || mapped[0].Name != "a" || !mapped[0].Started {
+		t.Errorf("unexpected mapped success %+v", mapped[0])
+	}
+	if mapped[1].Value != "" || mapped[1].Err != errBoom || mapped[1].Index != 1 {
+		t.Errorf("expected the failure to pass through, got %+v", mapped[1])
+	}
+}
@@ -0,0 +1,96 @@
+package gocrc
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// RunMode selects the semantics of Run.
+type RunMode int
+
+const (
+	// RunAll waits for every worker, like NoRace.
+	RunAll RunMode = iota
+	// RunRace returns the first worker to complete and cancels the others, like Race.
+	RunRace
+)
+
+// Config describes a Run call in one value, as an alternative to variadic workers plus options.
+// The zero value of each field means "not configured".
+type Config[T any] struct {
+	Workers []Worker[T]
+	Mode    RunMode
+
+	// Names labels the workers by position, as in WithNames.
+	Names []string
+	// Limit caps how many workers run at once. Zero or less means no limit.
+	Limit int
+	// Timeout bounds each worker, retries included, as in WithDynamicTimeout.
+	Timeout time.Duration
+	// Retry, if set, retries each worker according to the policy, as in WithBackoffConfig.
+	Retry *BackoffConfig
+	// RateLimit paces worker starts, as in WithRateLimit.
+	RateLimit Limiter
+	// Logger logs worker events, as in WithLogger.
+	Logger *slog.Logger
+	// Options are applied after the fields above, for everything Config has no field for.
+	Options []Option[T]
+}
+
+// Run runs cfg.Workers as configured. In RunAll mode it follows the contract of NoRace; in RunRace
+// mode it follows that of Race and returns the winning Result as the only element.
+func Run[T any](ctx context.Context, cfg Config[T]) ([]Result[T], error) {
+	workers := cfg.Workers
+	if cfg.Retry != nil {
+		policy := *cfg.Retry
+		workers = make([]Worker[T], len(cfg.Workers))
+		for i, w := range cfg.Workers {
+			workers[i] = WithBackoffConfig(policy, w)
+		}
+	}
+
+	var opts []Option[T]
+	if cfg.Names != nil {
+		opts = append(opts, WithNames[T](cfg.Names...))
+	}
+	if cfg.Limit > 0 {
+		opts = append(opts, WithAdaptiveLimit[T](cfg.Limit, nil))
+	}
+	if cfg.Timeout > 0 {
+		timeout := cfg.Timeout
+		opts = append(opts, WithDynamicTimeout[T](func(int) time.Duration { return timeout }))
+	}
+	if cfg.RateLimit != nil {
+		opts = append(opts, WithRateLimit[T](cfg.RateLimit))
+	}
+	if cfg.Logger != nil {
+		opts = append(opts, WithLogger[T](cfg.Logger))
+	}
+	opts = append(opts, cfg.Options...)
+
+	if cfg.Mode == RunRace {
+		res, err := RaceWith(ctx, opts, workers...)
+		return []Result[T]{res}, err
+	}
+	return NoRaceWith(ctx, opts, workers...)
+}
+
+// DefaultLimit is the concurrency limit applied by NoRaceDefault. It defaults to GOMAXPROCS at program
+// start, which suits CPU-bound workers; values below 1 mean runtime.GOMAXPROCS(0) at the time of the
+// call. It may be changed, typically during initialization, but not while NoRaceDefault is running.
+var DefaultLimit = runtime.GOMAXPROCS(0)
+
+// NoRaceDefault is like NoRace but runs at most DefaultLimit workers at a time, guarding against
+// goroutine explosions when no explicit limit was chosen: workers are admitted from the calling
+// goroutine, so no more than DefaultLimit worker goroutines exist at once. Once the context is done
+// no further workers are started; their Results carry ctx.Err().
+func NoRaceDefault[T any](ctx context.Context, workers ...Worker[T]) ([]Result[T], error) {
+	limit := DefaultLimit
+	if limit < 1 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	results := runLimited(ctx, limit, workers)
+	return results, collectErrors(results)
+}
@@ -0,0 +1,124 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all", func(t *testing.T) {
+		var active, peak int32
+		calls := make([]int32, 3)
+		flaky := func(i int) Worker[int] {
+			return func(ctx context.Context) (int, error) {
+				n := atomic.AddInt32(&active, 1)
+				defer atomic.AddInt32(&active, -1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				if atomic.AddInt32(&calls[i], 1) < 2 {
+					return 0, errors.New("flaky")
+				}
+				return i, nil
+			}
+		}
+
+		results, err := Run(ctx, Config[int]{
+			Workers: []Worker[int]{flaky(0), flaky(1), flaky(2)},
+			Names:   []string{"a", "b", "c"},
+			Limit:   1,
+			Retry:   &BackoffConfig{Attempts: 2},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for i, res := range results {
+			if res.Value != i || res.Name != []string{"a", "b", "c"}[i] {
+				t.Errorf("unexpected result %d: %+v", i, res)
+			}
+		}
+		if p := atomic.LoadInt32(&peak); p != 1 {
+			t.Errorf("expected peak concurrency 1, got %d", p)
+		}
+	})
+
+	t.Run("race_with_timeout", func(t *testing.T) {
+		slow := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+
+		results, err := Run(ctx, Config[int]{
+			Workers: []Worker[int]{slow},
+			Mode:    RunRace,
+			Timeout: 10 * time.Millisecond,
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded, got %v", err)
+		}
+		if len(results) != 1 || results[0].Index != 0 {
+			t.Errorf("expected the single worker's Result, got %v", results)
+		}
+	})
+}
+
+func TestNoRaceDefault(t *testing.T) {
+	defer func(limit int) { DefaultLimit = limit }(DefaultLimit)
+	DefaultLimit = 2
+
+	ctx := context.Background()
+	var active, crowded int32
+	w := func(ctx context.Context) (int, error) {
+		if atomic.AddInt32(&active, 1) > 2 {
+			atomic.AddInt32(&crowded, 1)
+		}
+		defer atomic.AddInt32(&active, -1)
+		time.Sleep(5 * time.Millisecond)
+		return 1, nil
+	}
+
+	results, err := NoRaceDefault(ctx, w, w, w, w, w)
+	if err != nil || len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d, %v", len(results), err)
+	}
+	if c := atomic.LoadInt32(&crowded); c != 0 {
+		t.Errorf("expected at most 2 concurrent workers, exceeded %d times", c)
+	}
+
+	t.Run("bounded_goroutines", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+		var peak atomic.Int64
+		w := func(ctx context.Context) (int, error) {
+			n := int64(runtime.NumGoroutine())
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			return 1, nil
+		}
+
+		workers := make([]Worker[int], 1000)
+		for i := range workers {
+			workers[i] = w
+		}
+		if _, err := NoRaceDefault(ctx, workers...); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		// Allow for the workers being run plus a little slack for runtime goroutines.
+		if p := peak.Load(); p > int64(before+DefaultLimit+2) {
+			t.Errorf("expected about %d goroutines at most, saw %d", before+DefaultLimit, p)
+		}
+	})
+}
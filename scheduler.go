@@ -0,0 +1,93 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// ErrInvalidSchedule is returned by NoRaceWith, before any worker runs, when the Scheduler set with
+// WithScheduler returns an order that is not a permutation of the worker indices.
+var ErrInvalidSchedule = errors.New("gocrc: scheduler order is not a permutation of the worker indices")
+
+// Scheduler decides the order in which NoRaceWith starts workers when set with WithScheduler.
+type Scheduler interface {
+	// Order returns a permutation of the indices [0, n) giving the start order.
+	Order(n int) []int
+}
+
+// SchedulerFunc adapts a function to the Scheduler interface.
+type SchedulerFunc func(n int) []int
+
+// Order calls f(n).
+func (f SchedulerFunc) Order(n int) []int {
+	return f(n)
+}
+
+// ReverseScheduler starts workers from the last index to the first.
+var ReverseScheduler Scheduler = SchedulerFunc(func(n int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = n - 1 - i
+	}
+	return order
+})
+
+// SeededScheduler starts workers in a pseudo-random order that is the same for every run with the same seed.
+func SeededScheduler(seed uint64) Scheduler {
+	return SchedulerFunc(func(n int) []int {
+		return rand.New(rand.NewPCG(seed, seed)).Perm(n)
+	})
+}
+
+// WithScheduler makes NoRaceWith run the workers one at a time in the order chosen by s, instead of
+// concurrently, so that tests of worker logic are reproducible. Results are still returned in index
+// order and CompletionOrder reflects the schedule. Once the context is done, the remaining workers
+// are not started and their Results carry ctx.Err(). An order that is not a permutation of the
+// indices fails the call with ErrInvalidSchedule before any worker runs.
+func WithScheduler[T any](s Scheduler) Option[T] {
+	return func(o *options[T]) {
+		o.scheduler = s
+	}
+}
+
+// runScheduled is the NoRaceWith path used when a Scheduler is set.
+func runScheduled[T any](ctx context.Context, o *options[T], workers []Worker[T]) ([]Result[T], error) {
+	order := o.scheduler.Order(len(workers))
+	if err := checkPermutation(order, len(workers)); err != nil {
+		return nil, err
+	}
+
+	results := o.resultSlice(len(workers))
+	for rank, index := range order {
+		var res Result[T]
+		if err := ctx.Err(); err != nil {
+			res = Result[T]{Err: err, Index: index, Name: o.name(index)}
+		} else {
+			res = o.exec(ctx, index, workers[index])
+		}
+		res.CompletionOrder = rank
+		results[index] = res
+		o.observe(res, rank+1, len(workers))
+	}
+	return results, collectErrors(results)
+}
+
+// checkPermutation reports, wrapped in ErrInvalidSchedule, why order is not a permutation of [0, n).
+func checkPermutation(order []int, n int) error {
+	if len(order) != n {
+		return fmt.Errorf("%w: got %d indices for %d workers", ErrInvalidSchedule, len(order), n)
+	}
+	seen := make([]bool, n)
+	for _, index := range order {
+		if index < 0 || index >= n {
+			return fmt.Errorf("%w: index %d out of range", ErrInvalidSchedule, index)
+		}
+		if seen[index] {
+			return fmt.Errorf("%w: index %d repeated", ErrInvalidSchedule, index)
+		}
+		seen[index] = true
+	}
+	return nil
+}
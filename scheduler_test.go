@@ -0,0 +1,83 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithScheduler(t *testing.T) {
+	run := func(s Scheduler) ([]int, []Result[int]) {
+		var order []int
+		var workers []Worker[int]
+		for i := range 5 {
+			workers = append(workers, func(ctx context.Context) (int, error) {
+				order = append(order, i)
+				return i, nil
+			})
+		}
+
+		results, err := NoRaceWith(context.Background(), []Option[int]{WithScheduler[int](s)}, workers...)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		return order, results
+	}
+
+	t.Run("reverse", func(t *testing.T) {
+		order, results := run(ReverseScheduler)
+		if !slices.Equal(order, []int{4, 3, 2, 1, 0}) {
+			t.Errorf("expected reverse start order, got %v", order)
+		}
+		for i, r := range results {
+			if r.Index != i || r.Value != i {
+				t.Errorf("expected results in index order, got %v", results)
+			}
+			if r.CompletionOrder != 4-i {
+				t.Errorf("worker %d: expected rank %d, got %d", i, 4-i, r.CompletionOrder)
+			}
+		}
+	})
+
+	t.Run("seeded_is_reproducible", func(t *testing.T) {
+		first, _ := run(SeededScheduler(7))
+		second, _ := run(SeededScheduler(7))
+		if !slices.Equal(first, second) {
+			t.Errorf("expected the same order for the same seed, got %v and %v", first, second)
+		}
+
+		sorted := slices.Clone(first)
+		slices.Sort(sorted)
+		if !slices.Equal(sorted, []int{0, 1, 2, 3, 4}) {
+			t.Errorf("expected a permutation, got %v", first)
+		}
+	})
+}
+
+func TestWithSchedulerInvalidOrder(t *testing.T) {
+	for name, order := range map[string][]int{
+		"short":        {1},
+		"out_of_range": {0, 1, 3},
+		"negative":     {0, -1, 2},
+		"repeated":     {0, 1, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			w := func(ctx context.Context) (int, error) {
+				atomic.AddInt32(&calls, 1)
+				return 1, nil
+			}
+			s := SchedulerFunc(func(n int) []int { return order })
+
+			results, err := NoRaceWith(context.Background(), []Option[int]{WithScheduler[int](s)}, w, w, w)
+			if !errors.Is(err, ErrInvalidSchedule) {
+				t.Errorf("expected ErrInvalidSchedule, got %v", err)
+			}
+			if results != nil || atomic.LoadInt32(&calls) != 0 {
+				t.Errorf("expected no worker to run, got %d calls and %v", calls, results)
+			}
+		})
+	}
+}
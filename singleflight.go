@@ -0,0 +1,50 @@
+package gocrc
+
+import (
+	"context"
+	"sync"
+)
+
+// SingleFlight collapses concurrent calls sharing a key into a single worker invocation,
+// in the spirit of golang.org/x/sync/singleflight. The zero value is ready to use.
+type SingleFlight[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+}
+
+type flightCall[T any] struct {
+	done   chan struct{}
+	res    Result[T]
+	shared bool
+}
+
+// Do runs w for key unless a call for the same key is already in flight, in which case it waits for
+// that call and returns its Result. shared reports whether the Result was handed to more than one caller.
+// The worker runs under context.Background(), since it serves every caller sharing the key.
+func (sf *SingleFlight[T]) Do(key string, w Worker[T]) (res Result[T], shared bool, err error) {
+	sf.mu.Lock()
+	if sf.calls == nil {
+		sf.calls = make(map[string]*flightCall[T])
+	}
+	if c, ok := sf.calls[key]; ok {
+		c.shared = true
+		sf.mu.Unlock()
+		<-c.done
+		return c.res, true, c.res.Err
+	}
+
+	c := &flightCall[T]{done: make(chan struct{})}
+	sf.calls[key] = c
+	sf.mu.Unlock()
+
+	val, callErr := call(context.Background(), w)
+	c.res = Result[T]{Value: val, Err: callErr, Index: IndexCancelled, Name: key, Started: true}
+
+	sf.mu.Lock()
+	delete(sf.calls, key)
+	shared = c.shared
+	sf.mu.Unlock()
+	close(c.done)
+
+	return c.res, shared, c.res.Err
+}
@@ -0,0 +1,78 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleFlight(t *testing.T) {
+	t.Run("collapses_duplicates", func(t *testing.T) {
+		var sf SingleFlight[string]
+		var calls int32
+		release := make(chan struct{})
+
+		w := func(ctx context.Context) (string, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return "user-42", nil
+		}
+
+		var wg sync.WaitGroup
+		var sharedCount int32
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, shared, err := sf.Do("user:42", w)
+				if err != nil || res.Value != "user-42" || res.Index != IndexCancelled {
+					t.Errorf("unexpected result: %v, %v", res, err)
+				}
+				if shared {
+					atomic.AddInt32(&sharedCount, 1)
+				}
+			}()
+		}
+
+		time.Sleep(30 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if c := atomic.LoadInt32(&calls); c != 1 {
+			t.Errorf("expected 1 call, got %d", c)
+		}
+		if s := atomic.LoadInt32(&sharedCount); s != 5 {
+			t.Errorf("expected every caller to see a shared result, got %d", s)
+		}
+	})
+
+	t.Run("sequential_calls_run_again", func(t *testing.T) {
+		var sf SingleFlight[int]
+		var calls int32
+		w := func(ctx context.Context) (int, error) {
+			return int(atomic.AddInt32(&calls, 1)), nil
+		}
+
+		first, shared, _ := sf.Do("k", w)
+		second, _, _ := sf.Do("k", w)
+		if shared {
+			t.Errorf("expected a lone call not to be shared")
+		}
+		if first.Value != 1 || second.Value != 2 {
+			t.Errorf("expected two separate runs, got %d and %d", first.Value, second.Value)
+		}
+	})
+
+	t.Run("error_is_shared", func(t *testing.T) {
+		var sf SingleFlight[int]
+		boom := errors.New("boom")
+
+		_, _, err := sf.Do("k", func(ctx context.Context) (int, error) { return 0, boom })
+		if err != boom {
+			t.Errorf("expected %v, got %v", boom, err)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Stats summarizes a NoRaceWithStats call.
+type Stats struct {
+	// Workers is the number of workers given.
+	Workers int
+	// Peak is the highest number of worker bodies that were running at the same time. Workers held
+	// back by a limit, e.g. waiting on WithRateLimit or WithAdaptiveLimit, do not count.
+	Peak int
+	// Succeeded and Failed count the Results without and with an error.
+	Succeeded int
+	Failed    int
+	// Leaked counts the failed workers abandoned with ErrAbandoned, e.g. by WithEscalatingTimeout,
+	// whose goroutines were left running because they ignored the cancellation of their context.
+	Leaked int
+	// Wall is the elapsed time of the whole call.
+	Wall time.Duration
+}
+
+// NoRaceWithStats is like NoRaceWith but also reports Stats about the run, which helps tune
+// concurrency limits empirically. Tracking costs a few atomic operations per worker.
+func NoRaceWithStats[T any](ctx context.Context, opts []Option[T], workers ...Worker[T]) ([]Result[T], Stats, error) {
+	tracker := &concurrency{}
+	opts = append(opts[:len(opts):len(opts)], func(o *options[T]) {
+		o.concurrency = tracker
+	})
+
+	start := time.Now()
+	results, err := NoRaceWith(ctx, opts, workers...)
+	stats := Stats{
+		Workers: len(workers),
+		Peak:    int(tracker.peak.Load()),
+		Wall:    time.Since(start),
+	}
+	for _, res := range results {
+		if res.Err != nil {
+			stats.Failed++
+			if errors.Is(res.Err, ErrAbandoned) {
+				stats.Leaked++
+			}
+		} else {
+			stats.Succeeded++
+		}
+	}
+	return results, stats, err
+}
+
+// concurrency tracks how many worker bodies are running and the most there ever were.
+type concurrency struct {
+	active atomic.Int64
+	peak   atomic.Int64
+}
+
+func (c *concurrency) enter() {
+	n := c.active.Add(1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			return
+		}
+	}
+}
+
+func (c *concurrency) exit() {
+	c.active.Add(-1)
+}
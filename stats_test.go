@@ -0,0 +1,51 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNoRaceWithStats(t *testing.T) {
+	ctx := context.Background()
+
+	sleepy := func(ctx context.Context) (int, error) {
+		time.Sleep(20 * time.Millisecond)
+		return 1, nil
+	}
+	fail := func(ctx context.Context) (int, error) {
+		time.Sleep(20 * time.Millisecond)
+		return 0, errors.New("boom")
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		_, stats, err := NoRaceWithStats(ctx, nil, sleepy, sleepy, sleepy, fail)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if stats.Workers != 4 || stats.Succeeded != 3 || stats.Failed != 1 {
+			t.Errorf("unexpected counts %+v", stats)
+		}
+		if stats.Peak != 4 {
+			t.Errorf("expected peak 4, got %d", stats.Peak)
+		}
+		if stats.Wall < 20*time.Millisecond {
+			t.Errorf("expected wall time of at least 20ms, got %v", stats.Wall)
+		}
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		opts := []Option[int]{WithAdaptiveLimit[int](2, nil)}
+		_, stats, err := NoRaceWithStats(ctx, opts, sleepy, sleepy, sleepy, sleepy, sleepy)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if stats.Peak < 1 || stats.Peak > 2 {
+			t.Errorf("expected peak of at most 2, got %d", stats.Peak)
+		}
+		if stats.Succeeded != 5 {
+			t.Errorf("expected 5 successes, got %d", stats.Succeeded)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package gocrc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Stream runs multiple workers concurrently and sends each Result on the returned channel as soon as
+// its worker finishes. The channel is closed once every worker has completed.
+// The channel is buffered to the number of workers, so a finished worker never blocks on a slow consumer.
+// Breaking out of a range over the channel does not stop the remaining workers; use StreamCancel for that.
+func Stream[T any](ctx context.Context, workers ...Worker[T]) <-chan Result[T] {
+	out := make(chan Result[T], len(workers))
+	ctx = withTotal(ctx, len(workers))
+
+	var wg sync.WaitGroup
+	var completed atomic.Int64
+	wg.Add(len(workers))
+	for i := range workers {
+		index := i
+		worker := workers[i]
+		go func() {
+			defer wg.Done()
+			res := invoke(ctx, index, worker)
+			res.CompletionOrder = int(completed.Add(1) - 1)
+			out <- res
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// NoRaceInto runs multiple workers concurrently and sends each Result on out as soon as its worker
+// finishes, then returns the aggregate error as NoRace would. The caller owns out: it is never closed,
+// so one channel can collect several batches. A send still pending when the context is done is
+// dropped rather than blocking the worker; if any was, the error wraps ErrCancelled and ctx.Err().
+func NoRaceInto[T any](ctx context.Context, out chan<- Result[T], workers ...Worker[T]) error {
+	results := make([]Result[T], len(workers))
+	ctx = withTotal(ctx, len(workers))
+	var dropped atomic.Bool
+
+	var wg sync.WaitGroup
+	var completed atomic.Int64
+	wg.Add(len(workers))
+	for i := range workers {
+		index := i
+		worker := workers[i]
+		go func() {
+			defer wg.Done()
+			res := invoke(ctx, index, worker)
+			res.CompletionOrder = int(completed.Add(1) - 1)
+			results[index] = res
+			select {
+			case out <- res:
+			case <-ctx.Done():
+				dropped.Store(true)
+			}
+		}()
+	}
+
+	wg.Wait()
+	if dropped.Load() {
+		return fmt.Errorf("%w: %w", ErrCancelled, ctx.Err())
+	}
+	return collectErrors(results)
+}
+
+// StreamCancel is like Stream but runs the workers under a context derived from ctx and returns its
+// cancel function, so that a consumer who stops early, e.g. on the first success, can stop the
+// remaining workers too. The channel is still closed once every worker has returned.
+func StreamCancel[T any](ctx context.Context, workers ...Worker[T]) (<-chan Result[T], context.CancelFunc) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	return Stream(streamCtx, workers...), cancel
+}
+
+// Drain receives and discards the remaining Results of ch until it is closed, and returns how many
+// there were. Channels whose sends can block, such as those of StreamLimit and Merge, keep their
+// goroutines alive until the channel is drained; cancelling the context first makes draining quick.
+func Drain[T any](ch <-chan Result[T]) int {
+	n := 0
+	for range ch {
+		n++
+	}
+	return n
+}
+
+// StreamLimit is like Stream but runs at most concurrency workers at a time (concurrency <= 0 means
+// no limit) and buffers at most bufferSize results. A worker keeps its concurrency slot until its Result
+// has been handed to the channel, so once bufferSize results are waiting, a slow consumer stops new
+// workers from being scheduled: memory held in flight is bounded by concurrency + bufferSize results.
+// Workers start in index order. Once the context is done no further workers are started; their
+// Results carry ctx.Err(). The consumer must keep receiving until the channel is closed.
+func StreamLimit[T any](ctx context.Context, concurrency, bufferSize int, workers ...Worker[T]) <-chan Result[T] {
+	out := make(chan Result[T], max(bufferSize, 0))
+	ctx = withTotal(ctx, len(workers))
+	if concurrency <= 0 || concurrency > len(workers) {
+		concurrency = max(len(workers), 1)
+	}
+
+	go func() {
+		defer close(out)
+
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		var completed atomic.Int64
+		for i := range workers {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+			if err := ctx.Err(); err != nil {
+				wg.Wait()
+				for j := i; j < len(workers); j++ {
+					out <- Result[T]{Err: err, Index: j, CompletionOrder: int(completed.Add(1) - 1)}
+				}
+				return
+			}
+
+			index := i
+			worker := workers[i]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				res := invoke(ctx, index, worker)
+				res.CompletionOrder = int(completed.Add(1) - 1)
+				out <- res
+			}()
+		}
+		wg.Wait()
+	}()
+	return out
+}
+
+// StreamOrdered is like Stream but emits results strictly in index order: the Result of worker k is
+// released only once every worker below k has completed.
+// Out-of-order completions are held internally, so while an early worker is slow up to
+// len(workers)-1 results may be buffered. The channel is closed once every worker has completed,
+// which on cancellation happens as soon as the workers honour the context.
+func StreamOrdered[T any](ctx context.Context, workers ...Worker[T]) <-chan Result[T] {
+	out := make(chan Result[T], len(workers))
+	in := Stream(ctx, workers...)
+
+	go func() {
+		defer close(out)
+		pending := make(map[int]Result[T])
+		next := 0
+		for res := range in {
+			pending[res.Index] = res
+			for {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				out <- r
+				next++
+			}
+		}
+	}()
+	return out
+}
+
+// Merge fans multiple result channels into one. Results are forwarded as they arrive, so a slow
+// input never holds back the others, and the output is closed once every input has been drained.
+func Merge[T any](chans ...<-chan Result[T]) <-chan Result[T] {
+	out := make(chan Result[T])
+
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+	for _, ch := range chans {
+		go func() {
+			defer wg.Done()
+			for res := range ch {
+				out <- res
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
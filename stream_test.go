@@ -0,0 +1,246 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	t.Run("results_in_completion_order", func(t *testing.T) {
+		ctx := context.Background()
+		w1 := func(ctx context.Context) (int, error) {
+			time.Sleep(60 * time.Millisecond)
+			return 1, nil
+		}
+		w2 := func(ctx context.Context) (int, error) { return 2, nil }
+		w3 := func(ctx context.Context) (int, error) {
+			time.Sleep(20 * time.Millisecond)
+			return 0, errors.New("boom")
+		}
+
+		var order []int
+		for res := range Stream(ctx, w1, w2, w3) {
+			order = append(order, res.Index)
+		}
+
+		if len(order) != 3 {
+			t.Fatalf("expected 3 results, got %d", len(order))
+		}
+		if order[0] != 1 || order[1] != 2 || order[2] != 0 {
+			t.Errorf("expected completion order [1 2 0], got %v", order)
+		}
+	})
+
+	t.Run("no_workers_closes", func(t *testing.T) {
+		if _, ok := <-Stream[int](context.Background()); ok {
+			t.Errorf("expected closed channel")
+		}
+	})
+}
+
+func TestStreamOrdered(t *testing.T) {
+	t.Run("releases_in_index_order", func(t *testing.T) {
+		ctx := context.Background()
+		var workers []Worker[int]
+		for i, d := range []time.Duration{60, 10, 40, 0} {
+			workers = append(workers, func(ctx context.Context) (int, error) {
+				time.Sleep(d * time.Millisecond)
+				return i, nil
+			})
+		}
+
+		var got []int
+		for res := range StreamOrdered(ctx, workers...) {
+			if res.Value != res.Index {
+				t.Errorf("value %d does not match index %d", res.Value, res.Index)
+			}
+			got = append(got, res.Index)
+		}
+
+		if len(got) != 4 {
+			t.Fatalf("expected 4 results, got %d", len(got))
+		}
+		for i, idx := range got {
+			if idx != i {
+				t.Errorf("expected index order, got %v", got)
+				break
+			}
+		}
+	})
+
+	t.Run("closes_on_cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		w := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+
+		ch := StreamOrdered(ctx, w, w)
+		cancel()
+
+		n := 0
+		for res := range ch {
+			if !errors.Is(res.Err, context.Canceled) {
+				t.Errorf("expected context.Canceled, got %v", res.Err)
+			}
+			n++
+		}
+		if n != 2 {
+			t.Errorf("expected 2 results, got %d", n)
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	ctx := context.Background()
+	slow := Stream(ctx, func(ctx context.Context) (string, error) {
+		time.Sleep(50 * time.Millisecond)
+		return "slow", nil
+	})
+	fast := Stream(ctx,
+		func(ctx context.Context) (string, error) { return "fast-1", nil },
+		func(ctx context.Context) (string, error) { return "fast-2", nil },
+	)
+
+	var got []string
+	for res := range Merge(slow, fast) {
+		got = append(got, res.Value)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 results, got %v", got)
+	}
+	if got[2] != "slow" {
+		t.Errorf("expected the slow input not to hold back the fast one, got %v", got)
+	}
+}
+
+func TestStreamLimit(t *testing.T) {
+	t.Run("slow_consumer_throttles", func(t *testing.T) {
+		ctx := context.Background()
+		var started int32
+
+		var workers []Worker[int]
+		for i := range 10 {
+			workers = append(workers, func(ctx context.Context) (int, error) {
+				atomic.AddInt32(&started, 1)
+				return i, nil
+			})
+		}
+
+		ch := StreamLimit(ctx, 2, 1, workers...)
+		time.Sleep(50 * time.Millisecond)
+		// 1 buffered result + 2 workers blocked on sending, holding their slots.
+		if s := atomic.LoadInt32(&started); s > 3 {
+			t.Errorf("expected at most 3 workers started without a consumer, got %d", s)
+		}
+
+		n := 0
+		for range ch {
+			n++
+		}
+		if n != 10 {
+			t.Errorf("expected 10 results, got %d", n)
+		}
+	})
+
+	t.Run("cancel_stops_scheduling", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var started int32
+
+		w := func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&started, 1)
+			cancel()
+			return 1, nil
+		}
+
+		cancelled := 0
+		for res := range StreamLimit(ctx, 1, 10, w, w, w, w) {
+			if errors.Is(res.Err, context.Canceled) {
+				cancelled++
+			}
+		}
+		if s := atomic.LoadInt32(&started); s != 1 {
+			t.Errorf("expected 1 worker started, got %d", s)
+		}
+		if cancelled != 3 {
+			t.Errorf("expected 3 unstarted workers reported as cancelled, got %d", cancelled)
+		}
+	})
+}
+
+func TestStreamCancel(t *testing.T) {
+	var cancelled int32
+	fast := func(ctx context.Context) (int, error) { return 1, nil }
+	slow := func(ctx context.Context) (int, error) {
+		select {
+		case <-time.After(time.Second):
+			return 2, nil
+		case <-ctx.Done():
+			atomic.AddInt32(&cancelled, 1)
+			return 0, ctx.Err()
+		}
+	}
+
+	ch, cancel := StreamCancel(context.Background(), fast, slow, slow)
+	first := <-ch
+	if first.Value != 1 {
+		t.Fatalf("expected the fast worker first, got %+v", first)
+	}
+
+	start := time.Now()
+	cancel()
+	if n := Drain(ch); n != 2 {
+		t.Errorf("expected 2 drained results, got %d", n)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected cancellation to stop the slow workers, took %v", elapsed)
+	}
+	if c := atomic.LoadInt32(&cancelled); c != 2 {
+		t.Errorf("expected 2 cancelled workers, got %d", c)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	w := func(ctx context.Context) (int, error) { return 1, nil }
+	ch := StreamLimit(context.Background(), 1, 0, w, w, w)
+	<-ch
+	if n := Drain(ch); n != 2 {
+		t.Errorf("expected 2 drained results, got %d", n)
+	}
+}
+
+func TestNoRaceInto(t *testing.T) {
+	t.Run("fans_batches_into_one_channel", func(t *testing.T) {
+		ctx := context.Background()
+		out := make(chan Result[int], 4)
+		w := func(ctx context.Context) (int, error) { return 1, nil }
+		fail := func(ctx context.Context) (int, error) { return 0, errors.New("boom") }
+
+		if err := NoRaceInto(ctx, out, w, w); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		err := NoRaceInto(ctx, out, w, fail)
+		if _, ok := err.(*MultiError[int]); !ok {
+			t.Fatalf("expected *MultiError[int], got %v", err)
+		}
+		if len(out) != 4 {
+			t.Errorf("expected 4 results in the channel, got %d", len(out))
+		}
+	})
+
+	t.Run("non_reading_consumer", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		out := make(chan Result[int])
+		w := func(ctx context.Context) (int, error) { return 1, nil }
+
+		err := NoRaceInto(ctx, out, w, w)
+		if !errors.Is(err, ErrCancelled) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected ErrCancelled wrapping the deadline, got %v", err)
+		}
+	})
+}
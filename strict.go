@@ -0,0 +1,27 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoWorkers is returned by the strict variants when they are called without any workers.
+var ErrNoWorkers = errors.New("gocrc: no workers")
+
+// RaceStrict is like Race but returns ErrNoWorkers, with an IndexCancelled Result, when workers is
+// empty instead of silently succeeding with a zero Result.
+func RaceStrict[T any](ctx context.Context, workers ...Worker[T]) (Result[T], error) {
+	if len(workers) == 0 {
+		return Result[T]{Index: IndexCancelled, Err: ErrNoWorkers}, ErrNoWorkers
+	}
+	return Race(ctx, workers...)
+}
+
+// NoRaceStrict is like NoRace but returns ErrNoWorkers when workers is empty instead of
+// silently succeeding with no results.
+func NoRaceStrict[T any](ctx context.Context, workers ...Worker[T]) ([]Result[T], error) {
+	if len(workers) == 0 {
+		return nil, ErrNoWorkers
+	}
+	return NoRace(ctx, workers...)
+}
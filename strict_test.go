@@ -0,0 +1,44 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("race_empty", func(t *testing.T) {
+		res, err := RaceStrict[int](ctx)
+		if !errors.Is(err, ErrNoWorkers) {
+			t.Errorf("expected ErrNoWorkers, got %v", err)
+		}
+		if res.Index != IndexCancelled {
+			t.Errorf("expected index %d, got %d", IndexCancelled, res.Index)
+		}
+	})
+
+	t.Run("norace_empty", func(t *testing.T) {
+		results, err := NoRaceStrict[int](ctx)
+		if !errors.Is(err, ErrNoWorkers) {
+			t.Errorf("expected ErrNoWorkers, got %v", err)
+		}
+		if results != nil {
+			t.Errorf("expected no results, got %v", results)
+		}
+	})
+
+	t.Run("non_empty", func(t *testing.T) {
+		w := func(ctx context.Context) (int, error) { return 7, nil }
+
+		res, err := RaceStrict(ctx, w)
+		if err != nil || res.Value != 7 {
+			t.Errorf("RaceStrict: expected 7, nil; got %d, %v", res.Value, err)
+		}
+		results, err := NoRaceStrict(ctx, w, w)
+		if err != nil || len(results) != 2 {
+			t.Errorf("NoRaceStrict: expected 2 results, nil; got %d, %v", len(results), err)
+		}
+	})
+}
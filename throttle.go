@@ -0,0 +1,56 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrThrottled is returned by a worker wrapped with ThrottleDrop when a call is dropped.
+var ErrThrottled = errors.New("gocrc: call dropped by throttle")
+
+// ThrottleMode decides what a throttled worker does with calls made before the interval has passed.
+type ThrottleMode int
+
+const (
+	// ThrottleDrop rejects such calls immediately with ErrThrottled.
+	ThrottleDrop ThrottleMode = iota
+	// ThrottleQueue delays such calls until their turn, one per interval.
+	ThrottleQueue
+)
+
+// Throttle wraps w so that it starts at most once per interval; calls in between are dropped
+// with ErrThrottled. It is safe for concurrent use.
+func Throttle[T any](interval time.Duration, w Worker[T]) Worker[T] {
+	return ThrottleWith(interval, ThrottleDrop, w)
+}
+
+// ThrottleWith is like Throttle but lets the caller choose between dropping and queuing calls.
+// Queued calls wait for their slot in call order and give up with ctx.Err() if their context ends first.
+func ThrottleWith[T any](interval time.Duration, mode ThrottleMode, w Worker[T]) Worker[T] {
+	var mu sync.Mutex
+	var next time.Time // Earliest start time of the next run
+
+	return func(ctx context.Context) (T, error) {
+		var zero T
+
+		mu.Lock()
+		now := time.Now()
+		at := next
+		if at.Before(now) {
+			at = now
+		}
+		if mode == ThrottleDrop && at.After(now) {
+			mu.Unlock()
+			return zero, ErrThrottled
+		}
+		next = at.Add(interval)
+		mu.Unlock()
+
+		if err := sleep(ctx, time.Until(at)); err != nil {
+			return zero, err
+		}
+		return w(ctx)
+	}
+}
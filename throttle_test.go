@@ -0,0 +1,93 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottle(t *testing.T) {
+	t.Run("drops_within_interval", func(t *testing.T) {
+		var runs int32
+		w := Throttle(50*time.Millisecond, func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&runs, 1)
+			return 1, nil
+		})
+
+		ctx := context.Background()
+		var dropped int32
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := w(ctx); errors.Is(err, ErrThrottled) {
+					atomic.AddInt32(&dropped, 1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if r := atomic.LoadInt32(&runs); r != 1 {
+			t.Errorf("expected 1 run, got %d", r)
+		}
+		if d := atomic.LoadInt32(&dropped); d != 9 {
+			t.Errorf("expected 9 dropped calls, got %d", d)
+		}
+
+		time.Sleep(60 * time.Millisecond)
+		if _, err := w(ctx); err != nil {
+			t.Errorf("expected a run after the interval, got %v", err)
+		}
+	})
+
+	t.Run("queues_calls", func(t *testing.T) {
+		var starts []time.Time
+		var mu sync.Mutex
+		w := ThrottleWith(20*time.Millisecond, ThrottleQueue, func(ctx context.Context) (int, error) {
+			mu.Lock()
+			starts = append(starts, time.Now())
+			mu.Unlock()
+			return 1, nil
+		})
+
+		ctx := context.Background()
+		var wg sync.WaitGroup
+		for range 3 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := w(ctx); err != nil {
+					t.Errorf("expected nil error, got %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(starts) != 3 {
+			t.Fatalf("expected 3 runs, got %d", len(starts))
+		}
+		if span := starts[2].Sub(starts[0]); span < 35*time.Millisecond {
+			t.Errorf("expected runs spread over >= 40ms, got %v", span)
+		}
+	})
+
+	t.Run("queued_call_cancelled", func(t *testing.T) {
+		w := ThrottleWith(time.Second, ThrottleQueue, func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		if _, err := w(ctx); err != nil {
+			t.Fatalf("expected the first call to run, got %v", err)
+		}
+		if _, err := w(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+	})
+}
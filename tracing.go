@@ -0,0 +1,54 @@
+package gocrc
+
+import (
+	"context"
+	"strconv"
+)
+
+// Tracer starts spans for WithTracing. It is deliberately small so that adapters for tracing
+// libraries stay trivial; for OpenTelemetry:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, gocrc.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) RecordError(err error) {
+//		s.Span.RecordError(err)
+//		s.Span.SetStatus(codes.Error, err.Error())
+//	}
+//	func (s otelSpan) End() { s.Span.End() }
+type Tracer interface {
+	// Start starts a span named name as a child of any span in ctx, and returns a context holding it.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	RecordError(err error)
+	End()
+}
+
+// WithTracing runs every worker inside its own span started by tracer, as a child of any span in the
+// context. The span is named after the worker, or "worker <index>" for an unnamed one, records the
+// worker's error, and ends when the worker returns. A nil tracer disables tracing.
+func WithTracing[T any](tracer Tracer) Option[T] {
+	return func(o *options[T]) {
+		o.tracer = tracer
+	}
+}
+
+// startSpan starts the span of the worker at index, if tracing is enabled.
+func (o *options[T]) startSpan(ctx context.Context, index int, name string) (context.Context, Span) {
+	if o.tracer == nil {
+		return ctx, nil
+	}
+	if name == "" {
+		name = "worker " + strconv.Itoa(index)
+	}
+	return o.tracer.Start(ctx, name)
+}
@@ -0,0 +1,75 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+)
+
+type spanKey struct{}
+
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+type fakeSpan struct {
+	name   string
+	parent string
+	err    error
+	ended  bool
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	span := &fakeSpan{name: name, parent: parent}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, name), span
+}
+
+func (s *fakeSpan) RecordError(err error) { s.err = err }
+func (s *fakeSpan) End()                  { s.ended = true }
+
+func TestWithTracing(t *testing.T) {
+	ctx := context.WithValue(context.Background(), spanKey{}, "request")
+	errBoom := errors.New("boom")
+	var inner string
+
+	ok := func(ctx context.Context) (int, error) {
+		inner, _ = ctx.Value(spanKey{}).(string)
+		return 1, nil
+	}
+	fail := func(ctx context.Context) (int, error) { return 0, errBoom }
+
+	tracer := &fakeTracer{}
+	opts := []Option[int]{WithTracing[int](tracer), WithNames[int]("fetch")}
+	NoRaceWith(ctx, opts, ok, fail)
+
+	sort.Slice(tracer.spans, func(i, j int) bool { return tracer.spans[i].name < tracer.spans[j].name })
+	if len(tracer.spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(tracer.spans))
+	}
+	fetch, unnamed := tracer.spans[0], tracer.spans[1]
+	if fetch.name != "fetch" || unnamed.name != "worker 1" {
+		t.Errorf("expected spans [fetch, worker 1], got [%s, %s]", fetch.name, unnamed.name)
+	}
+	for _, span := range tracer.spans {
+		if span.parent != "request" || !span.ended {
+			t.Errorf("expected span %q to be an ended child of the request span, got %+v", span.name, span)
+		}
+	}
+	if fetch.err != nil || unnamed.err != errBoom {
+		t.Errorf("expected only the failing span to record an error, got %v and %v", fetch.err, unnamed.err)
+	}
+	if inner != "fetch" {
+		t.Errorf("expected the worker to run inside its span, got %q", inner)
+	}
+
+	if _, err := NoRaceWith(ctx, []Option[int]{WithTracing[int](nil)}, ok); err != nil {
+		t.Errorf("expected a nil tracer to be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package gocrc
+
+import "context"
+
+// erase adapts a typed worker to Worker[any] so that workers of different types can share one run.
+func erase[T any](w Worker[T]) Worker[any] {
+	return func(ctx context.Context) (any, error) {
+		val, err := w(ctx)
+		return val, err
+	}
+}
+
+// Race2 races two workers of different types. Whichever completes first (successfully or with error)
+// wins and the other is cancelled through the shared context. Only the winner's pointer is non-nil,
+// and the returned error is the winner's error. If ctx is done first, both pointers are nil.
+func Race2[A, B any](ctx context.Context, wa Worker[A], wb Worker[B]) (*A, *B, error) {
+	res, err := Race(ctx, erase(wa), erase(wb))
+	switch res.Index {
+	case 0:
+		a, _ := res.Value.(A)
+		return &a, nil, err
+	case 1:
+		b, _ := res.Value.(B)
+		return nil, &b, err
+	}
+	return nil, nil, err
+}
+
+// Join2 runs two workers of different types to completion and returns both values.
+// If either fails, the error is a *MultiError[any] whose Results identify the failing worker by Index.
+func Join2[A, B any](ctx context.Context, wa Worker[A], wb Worker[B]) (A, B, error) {
+	results, err := NoRace(ctx, erase(wa), erase(wb))
+	a, _ := results[0].Value.(A)
+	b, _ := results[1].Value.(B)
+	return a, b, err
+}
+
+// Join3 is like Join2 for three workers.
+func Join3[A, B, C any](ctx context.Context, wa Worker[A], wb Worker[B], wc Worker[C]) (A, B, C, error) {
+	results, err := NoRace(ctx, erase(wa), erase(wb), erase(wc))
+	a, _ := results[0].Value.(A)
+	b, _ := results[1].Value.(B)
+	c, _ := results[2].Value.(C)
+	return a, b, c, err
+}
+
+// Join4 is like Join2 for four workers.
+func Join4[A, B, C, D any](ctx context.Context, wa Worker[A], wb Worker[B], wc Worker[C], wd Worker[D]) (A, B, C, D, error) {
+	results, err := NoRace(ctx, erase(wa), erase(wb), erase(wc), erase(wd))
+	a, _ := results[0].Value.(A)
+	b, _ := results[1].Value.(B)
+	c, _ := results[2].Value.(C)
+	d, _ := results[3].Value.(D)
+	return a, b, c, d, err
+}
+
+// Results3 holds the typed Results of NoRace3, one field per worker.
+type Results3[A, B, C any] struct {
+	A Result[A]
+	B Result[B]
+	C Result[C]
+}
+
+// NoRace3 is like Join3 but returns each worker's full Result, timing and error included, in a typed
+// field. The aggregate error is a *MultiError[any] as for Join3.
+func NoRace3[A, B, C any](ctx context.Context, wa Worker[A], wb Worker[B], wc Worker[C]) (Results3[A, B, C], error) {
+	results, err := NoRace(ctx, erase(wa), erase(wb), erase(wc))
+	return Results3[A, B, C]{
+		A: unerase[A](results[0]),
+		B: unerase[B](results[1]),
+		C: unerase[C](results[2]),
+	}, err
+}
+
+// unerase converts a Result produced by an erased worker back to its typed form.
+func unerase[T any](res Result[any]) Result[T] {
+	val, _ := res.Value.(T)
+	return withValue(res, val)
+}
@@ -0,0 +1,141 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRace2(t *testing.T) {
+	type user struct{ Name string }
+
+	t.Run("first_type_wins", func(t *testing.T) {
+		ctx := context.Background()
+		var cancelled int32
+
+		cache := func(ctx context.Context) ([]byte, error) { return []byte("cached"), nil }
+		db := func(ctx context.Context) (user, error) {
+			select {
+			case <-time.After(500 * time.Millisecond):
+				return user{Name: "db"}, nil
+			case <-ctx.Done():
+				atomic.AddInt32(&cancelled, 1)
+				return user{}, ctx.Err()
+			}
+		}
+
+		a, b, err := Race2(ctx, cache, db)
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if a == nil || string(*a) != "cached" || b != nil {
+			t.Errorf("expected only the cache result, got %v %v", a, b)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		if atomic.LoadInt32(&cancelled) != 1 {
+			t.Errorf("expected the db worker to be cancelled")
+		}
+	})
+
+	t.Run("second_type_wins_with_error", func(t *testing.T) {
+		ctx := context.Background()
+		expectedErr := errors.New("db down")
+
+		cache := func(ctx context.Context) ([]byte, error) {
+			time.Sleep(200 * time.Millisecond)
+			return []byte("cached"), nil
+		}
+		db := func(ctx context.Context) (user, error) { return user{}, expectedErr }
+
+		a, b, err := Race2(ctx, cache, db)
+		if err != expectedErr {
+			t.Errorf("expected %v, got %v", expectedErr, err)
+		}
+		if a != nil || b == nil {
+			t.Errorf("expected only the db pointer to be set, got %v %v", a, b)
+		}
+	})
+}
+
+func TestJoin(t *testing.T) {
+	t.Run("join2_values", func(t *testing.T) {
+		ctx := context.Background()
+		n, s, err := Join2(ctx,
+			func(ctx context.Context) (int, error) { return 7, nil },
+			func(ctx context.Context) (string, error) { return "seven", nil },
+		)
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if n != 7 || s != "seven" {
+			t.Errorf("expected (7, seven), got (%d, %s)", n, s)
+		}
+	})
+
+	t.Run("join3_reports_failing_worker", func(t *testing.T) {
+		ctx := context.Background()
+		_, s, _, err := Join3(ctx,
+			func(ctx context.Context) (int, error) { return 1, nil },
+			func(ctx context.Context) (string, error) { return "ok", nil },
+			func(ctx context.Context) (bool, error) { return false, errors.New("boom") },
+		)
+
+		var merr *MultiError[any]
+		if !errors.As(err, &merr) {
+			t.Fatalf("expected *MultiError[any], got %T", err)
+		}
+		if len(merr.Results) != 1 || merr.Results[0].Index != 2 {
+			t.Errorf("expected worker 2 to fail, got %v", merr.Results)
+		}
+		if s != "ok" {
+			t.Errorf("expected successful values to be kept, got %q", s)
+		}
+	})
+
+	t.Run("join4_values", func(t *testing.T) {
+		ctx := context.Background()
+		a, b, c, d, err := Join4(ctx,
+			func(ctx context.Context) (int, error) { return 1, nil },
+			func(ctx context.Context) (string, error) { return "two", nil },
+			func(ctx context.Context) (float64, error) { return 3.0, nil },
+			func(ctx context.Context) ([]int, error) { return []int{4}, nil },
+		)
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if a != 1 || b != "two" || c != 3.0 || len(d) != 1 {
+			t.Errorf("unexpected values: %v %v %v %v", a, b, c, d)
+		}
+	})
+}
+
+func TestNoRace3(t *testing.T) {
+	ctx := context.Background()
+	errOffline := errors.New("offline")
+
+	res, err := NoRace3(ctx,
+		func(ctx context.Context) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 1, nil
+		},
+		func(ctx context.Context) (string, error) { return "two", nil },
+		func(ctx context.Context) (bool, error) { return false, errOffline },
+	)
+
+	var merr *MultiError[any]
+	if !errors.As(err, &merr) || len(merr.Results) != 1 || merr.Results[0].Index != 2 {
+		t.Fatalf("expected worker 2 to fail, got %v", err)
+	}
+	if res.A.Value != 1 || res.A.Index != 0 || res.A.FinishedAt.Sub(res.A.StartedAt) < 10*time.Millisecond {
+		t.Errorf("unexpected result A: %+v", res.A)
+	}
+	if res.B.Value != "two" || res.B.Err != nil || res.B.Index != 1 {
+		t.Errorf("unexpected result B: %+v", res.B)
+	}
+	if res.C.Err != errOffline || res.C.Index != 2 {
+		t.Errorf("unexpected result C: %+v", res.C)
+	}
+}
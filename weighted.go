@@ -0,0 +1,149 @@
+package gocrc
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrWeightTooLarge is reported for a worker whose weight exceeds the total capacity.
+var ErrWeightTooLarge = errors.New("gocrc: worker weight exceeds capacity")
+
+// NoRaceWeighted is like NoRace, but each worker acquires weights[i] units from a shared capacity
+// before running and releases them on completion, so cheap and heavy workers can share one limit.
+// Workers are admitted in index order. A worker whose weight exceeds capacity fails with
+// ErrWeightTooLarge instead of blocking forever, and workers still waiting when the context is done
+// fail with ctx.Err().
+func NoRaceWeighted[T any](ctx context.Context, capacity int64, weights []int64, workers ...Worker[T]) ([]Result[T], error) {
+	if len(workers) == 0 {
+		return nil, nil
+	}
+	if len(weights) != len(workers) {
+		return nil, fmt.Errorf("gocrc: got %d weights for %d workers", len(weights), len(workers))
+	}
+
+	sem := newWeighted(capacity)
+	results := make([]Result[T], len(workers))
+	ctx = withTotal(ctx, len(workers))
+	var wg sync.WaitGroup
+
+	for i := range workers {
+		index := i
+		worker := workers[i]
+		weight := max(weights[i], 0)
+
+		if weight > capacity {
+			results[index] = Result[T]{Err: ErrWeightTooLarge, Index: index}
+			continue
+		}
+		if err := sem.Acquire(ctx, weight); err != nil {
+			results[index] = Result[T]{Err: err, Index: index}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer sem.Release(weight)
+			results[index] = invoke(ctx, index, worker)
+		}()
+	}
+
+	wg.Wait()
+	return results, collectErrors(results)
+}
+
+// weighted is a FIFO weighted semaphore modelled on golang.org/x/sync/semaphore.
+type weighted struct {
+	size    int64
+	cur     int64
+	mu      sync.Mutex
+	waiters list.List
+}
+
+type waiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+func newWeighted(n int64) *weighted {
+	return &weighted{size: n}
+}
+
+// Acquire blocks until n units are available or ctx is done.
+func (s *weighted) Acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+
+	ready := make(chan struct{})
+	elem := s.waiters.PushBack(waiter{n: n, ready: ready})
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-ready:
+			// Acquired just as ctx was done; give the units back.
+			s.cur -= n
+			s.notifyWaiters()
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// Removing the front waiter may let the ones behind it proceed.
+			if isFront && s.size > s.cur {
+				s.notifyWaiters()
+			}
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Release returns n units to the semaphore.
+func (s *weighted) Release(n int64) {
+	s.mu.Lock()
+	s.cur -= n
+	if s.cur < 0 {
+		s.mu.Unlock()
+		panic("gocrc: semaphore released more than held")
+	}
+	s.notifyWaiters()
+	s.mu.Unlock()
+}
+
+func (s *weighted) notifyWaiters() {
+	for {
+		next := s.waiters.Front()
+		if next == nil {
+			break
+		}
+
+		w := next.Value.(waiter)
+		if s.size-s.cur < w.n {
+			// Strict FIFO: later waiters are not served ahead of the front one.
+			break
+		}
+
+		s.cur += w.n
+		s.waiters.Remove(next)
+		close(w.ready)
+	}
+}
+
+// Resize changes the capacity to n. Holders are never preempted: when shrinking below the units
+// currently held, waiters are admitted again only once enough units have been released.
+func (s *weighted) Resize(n int64) {
+	s.mu.Lock()
+	s.size = n
+	s.notifyWaiters()
+	s.mu.Unlock()
+}
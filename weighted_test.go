@@ -0,0 +1,91 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNoRaceWeighted(t *testing.T) {
+	t.Run("respects_capacity", func(t *testing.T) {
+		ctx := context.Background()
+		var used, peak int64
+
+		worker := func(weight int64) Worker[int64] {
+			return func(ctx context.Context) (int64, error) {
+				cur := atomic.AddInt64(&used, weight)
+				for {
+					old := atomic.LoadInt64(&peak)
+					if cur <= old || atomic.CompareAndSwapInt64(&peak, old, cur) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				atomic.AddInt64(&used, -weight)
+				return weight, nil
+			}
+		}
+
+		weights := []int64{3, 1, 1, 2, 4, 1}
+		var workers []Worker[int64]
+		for _, w := range weights {
+			workers = append(workers, worker(w))
+		}
+
+		results, err := NoRaceWeighted(ctx, 4, weights, workers...)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		for i, r := range results {
+			if r.Value != weights[i] || r.Index != i {
+				t.Errorf("result %d mismatch: %v", i, r)
+			}
+		}
+		if p := atomic.LoadInt64(&peak); p > 4 {
+			t.Errorf("expected peak weight <= 4, got %d", p)
+		}
+	})
+
+	t.Run("weight_exceeds_capacity", func(t *testing.T) {
+		ctx := context.Background()
+		w := func(ctx context.Context) (int, error) { return 1, nil }
+
+		results, err := NoRaceWeighted(ctx, 2, []int64{1, 5}, w, w)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !errors.Is(results[1].Err, ErrWeightTooLarge) {
+			t.Errorf("expected ErrWeightTooLarge, got %v", results[1].Err)
+		}
+		if results[0].Value != 1 || results[0].Err != nil {
+			t.Errorf("expected worker 0 to succeed, got %v", results[0])
+		}
+	})
+
+	t.Run("weights_length_mismatch", func(t *testing.T) {
+		ctx := context.Background()
+		w := func(ctx context.Context) (int, error) { return 1, nil }
+
+		if _, err := NoRaceWeighted(ctx, 2, []int64{1}, w, w); err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+
+	t.Run("cancel_while_waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		hog := func(ctx context.Context) (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 1, nil
+		}
+		waiting := func(ctx context.Context) (int, error) { return 2, nil }
+
+		results, _ := NoRaceWeighted(ctx, 2, []int64{2, 1}, hog, waiting)
+		if !errors.Is(results[1].Err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded for the waiting worker, got %v", results[1].Err)
+		}
+	})
+}
@@ -0,0 +1,243 @@
+package gocrc
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// WithTimeout wraps a worker so that it runs under its own deadline of d, derived from the
+// context it is given. If the worker overruns, it returns context.DeadlineExceeded.
+// The timeout is clamped to the parent's deadline: a 30s timeout under a context with 2s left
+// effectively becomes 2s, so the worker sees the parent's deadline and never waits in vain for
+// its own.
+func WithTimeout[T any](d time.Duration, w Worker[T]) Worker[T] {
+	return func(ctx context.Context) (T, error) {
+		timeoutCtx, cancel := context.WithTimeout(ctx, d)
+		defer cancel() // Release the timer as soon as the worker returns
+
+		val, err := w(timeoutCtx)
+		if err == nil && ctx.Err() == nil && timeoutCtx.Err() == context.DeadlineExceeded {
+			err = context.DeadlineExceeded
+		}
+		return val, err
+	}
+}
+
+// WithRetry wraps a worker so that it is re-invoked on error, up to attempts calls in total
+// (the first call included). The value of the first successful attempt or the last error is returned.
+// Retrying stops as soon as the context is done.
+func WithRetry[T any](attempts int, w Worker[T]) Worker[T] {
+	return func(ctx context.Context) (T, error) {
+		return retry(ctx, attempts, w, nil, nil)
+	}
+}
+
+// WithRetryIf is like WithRetry but only retries errors for which retryable reports true, such as
+// transient network errors. Any other error is returned at once without using up further attempts.
+func WithRetryIf[T any](attempts int, retryable func(error) bool, w Worker[T]) Worker[T] {
+	return func(ctx context.Context) (T, error) {
+		return retry(ctx, attempts, w, nil, retryable)
+	}
+}
+
+// BackoffConfig controls the delays used by WithBackoffConfig.
+type BackoffConfig struct {
+	// Attempts is the maximum number of calls, the first one included.
+	Attempts int
+	// Base is the delay before the first retry.
+	Base time.Duration
+	// Multiplier scales the delay after every retry. Values below 1 default to 2.
+	Multiplier float64
+	// Max caps a single delay. Zero means no cap.
+	Max time.Duration
+	// Jitter randomizes every delay by up to ±Jitter of its value, e.g. 0.5 for ±50%.
+	// Zero disables jitter. Delays never go below zero.
+	Jitter float64
+	// RetryIf, if set, decides whether an error is worth retrying, as in WithRetryIf.
+	RetryIf func(error) bool
+}
+
+func (c BackoffConfig) delay(retry int) time.Duration {
+	mult := c.Multiplier
+	if mult < 1 {
+		mult = 2
+	}
+
+	d := float64(c.Base) * math.Pow(mult, float64(retry))
+	if c.Max > 0 && d > float64(c.Max) {
+		d = float64(c.Max)
+	}
+	// float64(math.MaxInt64) rounds up to 2^63, which no longer converts to an int64.
+	if d >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
+
+// jittered spreads d by up to ±c.Jitter using rng.
+func (c BackoffConfig) jittered(d time.Duration, rng *rand.Rand) time.Duration {
+	if c.Jitter <= 0 {
+		return d
+	}
+
+	j := float64(d) * (1 + c.Jitter*(2*rng.Float64()-1))
+	if j < 0 {
+		return 0
+	}
+	if j >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(j)
+}
+
+// WithBackoff is like WithRetry but sleeps base * 2^n between attempts.
+func WithBackoff[T any](attempts int, base time.Duration, w Worker[T]) Worker[T] {
+	return WithBackoffConfig(BackoffConfig{Attempts: attempts, Base: base}, w)
+}
+
+// WithBackoffConfig is like WithRetry but sleeps between attempts according to cfg.
+// The sleep is interrupted, and the context error returned, as soon as the context is done.
+func WithBackoffConfig[T any](cfg BackoffConfig, w Worker[T]) Worker[T] {
+	return func(ctx context.Context) (T, error) {
+		delay := cfg.delay
+		if cfg.Jitter > 0 {
+			// A source per call keeps concurrent invocations from contending on shared state.
+			rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+			delay = func(retry int) time.Duration {
+				return cfg.jittered(cfg.delay(retry), rng)
+			}
+		}
+		return retry(ctx, cfg.Attempts, w, delay, cfg.RetryIf)
+	}
+}
+
+// retry calls w up to attempts times, sleeping delay(n) before the n-th retry when delay is non-nil.
+// When retryable is non-nil, an error it rejects ends the loop at once.
+func retry[T any](ctx context.Context, attempts int, w Worker[T], delay func(retry int) time.Duration, retryable func(error) bool) (T, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var val T
+	var err error
+	for attempt := range attempts {
+		if attempt > 0 && delay != nil {
+			if sleepErr := sleep(ctx, delay(attempt-1)); sleepErr != nil {
+				return val, sleepErr
+			}
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return val, ctxErr
+		}
+		val, err = w(ctx)
+		if err == nil {
+			return val, nil
+		}
+		if retryable != nil && !retryable(err) {
+			return val, err
+		}
+	}
+	return val, err
+}
+
+// sleep pauses for d or until the context is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Hedge wraps a worker to send hedged requests: it calls w, and every time delay passes without any
+// call having returned it starts another one, up to copies calls in total. The first call to return
+// wins, as in Race, and the others are cancelled, including copies not yet started.
+func Hedge[T any](delay time.Duration, w Worker[T], copies int) Worker[T] {
+	copies = max(copies, 1)
+	return func(ctx context.Context) (T, error) {
+		// The copies race among themselves rather than through Race, so that they keep the identity
+		// (IndexFromContext, NameFromContext, TotalFromContext) of the worker being hedged.
+		hedgeCtx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		type outcome struct {
+			val T
+			err error
+		}
+		// Buffered so that losing copies can deliver and exit.
+		done := make(chan outcome, copies)
+		for i := range copies {
+			go func() {
+				if err := sleep(hedgeCtx, time.Duration(i)*delay); err != nil {
+					done <- outcome{err: err}
+					return
+				}
+				val, err := call(hedgeCtx, w)
+				done <- outcome{val, err}
+			}()
+		}
+
+		select {
+		case out := <-done:
+			cancel(ErrRaceLost)
+			return out.val, out.err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// WithHardCancel wraps a worker that ignores its context so that it returns ctx.Err() as soon as the
+// context is done. The worker itself cannot be stopped: its goroutine is abandoned and keeps running
+// until the worker returns on its own, so every cancellation may leak a goroutine for that long.
+func WithHardCancel[T any](w Worker[T]) Worker[T] {
+	return WithHardCancelNotify(w, nil)
+}
+
+// WithHardCancelNotify is like WithHardCancel but delivers the Result of an abandoned worker on late
+// once it eventually returns. The send does not block, so late should be buffered; a nil late
+// discards such results.
+func WithHardCancelNotify[T any](w Worker[T], late chan<- Result[T]) Worker[T] {
+	return func(ctx context.Context) (T, error) {
+		// Buffered so that the worker goroutine can exit even when nobody is waiting for it.
+		done := make(chan Result[T], 1)
+		go func() {
+			res := Result[T]{StartedAt: time.Now(), Started: true}
+			res.Value, res.Err = call(ctx, w)
+			res.FinishedAt = time.Now()
+			res.Index = IndexCancelled
+			if index, ok := IndexFromContext(ctx); ok {
+				res.Index = index
+			}
+			res.Name, _ = NameFromContext(ctx)
+			done <- res
+		}()
+
+		select {
+		case res := <-done:
+			return res.Value, res.Err
+		case <-ctx.Done():
+			if late != nil {
+				go func() {
+					select {
+					case late <- <-done:
+					default:
+					}
+				}()
+			}
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,410 @@
+package gocrc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Run("finishes_in_time", func(t *testing.T) {
+		w := WithTimeout(100*time.Millisecond, func(ctx context.Context) (int, error) {
+			return 42, nil
+		})
+
+		val, err := w(context.Background())
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if val != 42 {
+			t.Errorf("expected 42, got %d", val)
+		}
+	})
+
+	t.Run("overruns", func(t *testing.T) {
+		w := WithTimeout(20*time.Millisecond, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+
+		_, err := w(context.Background())
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("clamped_to_parent", func(t *testing.T) {
+		parent, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		want, _ := parent.Deadline()
+
+		var got time.Time
+		w := WithTimeout(30*time.Second, func(ctx context.Context) (int, error) {
+			got, _ = ctx.Deadline()
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+
+		start := time.Now()
+		_, err := w(parent)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("expected the worker to see the parent's deadline %v, got %v", want, got)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected the parent's deadline to apply, took %v", elapsed)
+		}
+	})
+
+	t.Run("composes_with_noRace", func(t *testing.T) {
+		ctx := context.Background()
+		fast := WithTimeout(100*time.Millisecond, func(ctx context.Context) (string, error) {
+			return "fast", nil
+		})
+		slow := WithTimeout(20*time.Millisecond, func(ctx context.Context) (string, error) {
+			time.Sleep(50 * time.Millisecond)
+			return "slow", nil
+		})
+
+		results, err := NoRace(ctx, fast, slow)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if results[0].Value != "fast" || results[0].Err != nil {
+			t.Errorf("expected fast worker to succeed, got %v", results[0])
+		}
+		if !errors.Is(results[1].Err, context.DeadlineExceeded) {
+			t.Errorf("expected slow worker to overrun, got %v", results[1].Err)
+		}
+	})
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Run("succeeds_after_failures", func(t *testing.T) {
+		calls := 0
+		w := WithRetry(3, func(ctx context.Context) (string, error) {
+			calls++
+			if calls < 3 {
+				return "", errors.New("flaky")
+			}
+			return "ok", nil
+		})
+
+		val, err := w(context.Background())
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if val != "ok" || calls != 3 {
+			t.Errorf("expected 'ok' after 3 calls, got %v after %d", val, calls)
+		}
+	})
+
+	t.Run("returns_last_error", func(t *testing.T) {
+		calls := 0
+		w := WithRetry(3, func(ctx context.Context) (int, error) {
+			calls++
+			return 0, fmt.Errorf("attempt %d", calls)
+		})
+
+		_, err := w(context.Background())
+		if err == nil || err.Error() != "attempt 3" {
+			t.Errorf("expected 'attempt 3', got %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 calls, got %d", calls)
+		}
+	})
+
+	t.Run("stops_on_cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		w := WithRetry(5, func(ctx context.Context) (int, error) {
+			calls++
+			cancel()
+			return 0, errors.New("fail")
+		})
+
+		_, err := w(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 call, got %d", calls)
+		}
+	})
+}
+
+func TestWithRetryIf(t *testing.T) {
+	errTransient := errors.New("transient")
+	errInvalid := errors.New("invalid")
+	transient := func(err error) bool { return errors.Is(err, errTransient) }
+
+	t.Run("retries_transient", func(t *testing.T) {
+		calls := 0
+		w := WithRetryIf(3, transient, func(ctx context.Context) (int, error) {
+			calls++
+			if calls < 3 {
+				return 0, errTransient
+			}
+			return 1, nil
+		})
+
+		if val, err := w(context.Background()); err != nil || val != 1 || calls != 3 {
+			t.Errorf("expected 1 after 3 calls, got %v, %v after %d", val, err, calls)
+		}
+	})
+
+	t.Run("stops_on_permanent", func(t *testing.T) {
+		calls := 0
+		w := WithRetryIf(5, transient, func(ctx context.Context) (int, error) {
+			calls++
+			if calls == 1 {
+				return 0, errTransient
+			}
+			return 0, errInvalid
+		})
+
+		if _, err := w(context.Background()); err != errInvalid || calls != 2 {
+			t.Errorf("expected invalid after 2 calls, got %v after %d", err, calls)
+		}
+	})
+
+	t.Run("backoff_config", func(t *testing.T) {
+		calls := 0
+		cfg := BackoffConfig{Attempts: 5, Base: time.Millisecond, RetryIf: transient}
+		w := WithBackoffConfig(cfg, func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errInvalid
+		})
+
+		if _, err := w(context.Background()); err != errInvalid || calls != 1 {
+			t.Errorf("expected invalid after 1 call, got %v after %d", err, calls)
+		}
+	})
+}
+
+func TestWithBackoff(t *testing.T) {
+	t.Run("delays_grow", func(t *testing.T) {
+		var calls []time.Time
+		w := WithBackoff(3, 20*time.Millisecond, func(ctx context.Context) (int, error) {
+			calls = append(calls, time.Now())
+			return 0, errors.New("fail")
+		})
+
+		if _, err := w(context.Background()); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(calls) != 3 {
+			t.Fatalf("expected 3 calls, got %d", len(calls))
+		}
+		if gap := calls[1].Sub(calls[0]); gap < 20*time.Millisecond {
+			t.Errorf("expected first gap >= 20ms, got %v", gap)
+		}
+		if gap := calls[2].Sub(calls[1]); gap < 40*time.Millisecond {
+			t.Errorf("expected second gap >= 40ms, got %v", gap)
+		}
+	})
+
+	t.Run("cap_and_multiplier", func(t *testing.T) {
+		cfg := BackoffConfig{Base: 10 * time.Millisecond, Multiplier: 3, Max: 50 * time.Millisecond}
+		want := []time.Duration{10 * time.Millisecond, 30 * time.Millisecond, 50 * time.Millisecond}
+		for i, d := range want {
+			if got := cfg.delay(i); got != d {
+				t.Errorf("retry %d: expected %v, got %v", i, d, got)
+			}
+		}
+	})
+
+	t.Run("large_retry_saturates", func(t *testing.T) {
+		cfg := BackoffConfig{Base: time.Second}
+		for _, retry := range []int{34, 63, 100, 10000} {
+			if got := cfg.delay(retry); got != time.Duration(math.MaxInt64) {
+				t.Errorf("retry %d: expected the maximum delay, got %v", retry, got)
+			}
+		}
+
+		jitter := BackoffConfig{Base: time.Second, Jitter: 0.5}
+		rng := rand.New(rand.NewPCG(1, 2))
+		for range 100 {
+			if got := jitter.jittered(jitter.delay(100), rng); got <= 0 {
+				t.Fatalf("expected a positive jittered delay, got %v", got)
+			}
+		}
+	})
+
+	t.Run("cancel_interrupts_sleep", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		w := WithBackoff(3, time.Second, func(ctx context.Context) (int, error) {
+			return 0, errors.New("fail")
+		})
+
+		start := time.Now()
+		_, err := w(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("expected backoff to be interrupted, took %v", elapsed)
+		}
+	})
+}
+
+func TestBackoffJitter(t *testing.T) {
+	cfg := BackoffConfig{Base: 100 * time.Millisecond, Jitter: 0.5}
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for range 1000 {
+		d := cfg.jittered(cfg.delay(0), rng)
+		if d < 50*time.Millisecond || d > 150*time.Millisecond {
+			t.Fatalf("expected delay within ±50%%, got %v", d)
+		}
+	}
+
+	wide := BackoffConfig{Base: 100 * time.Millisecond, Jitter: 3}
+	for range 1000 {
+		if d := wide.jittered(wide.delay(0), rng); d < 0 {
+			t.Fatalf("expected non-negative delay, got %v", d)
+		}
+	}
+}
+
+func TestHedge(t *testing.T) {
+	t.Run("second_copy_wins", func(t *testing.T) {
+		var calls, cancelled int32
+		w := func(ctx context.Context) (int, error) {
+			n := atomic.AddInt32(&calls, 1)
+			d := 5 * time.Millisecond
+			if n == 1 {
+				d = time.Second // The first call hits the slow tail
+			}
+			select {
+			case <-time.After(d):
+				return int(n), nil
+			case <-ctx.Done():
+				atomic.AddInt32(&cancelled, 1)
+				return 0, ctx.Err()
+			}
+		}
+
+		start := time.Now()
+		val, err := Hedge(20*time.Millisecond, w, 3)(context.Background())
+		if err != nil || val != 2 {
+			t.Fatalf("expected the second copy to win, got %v, %v", val, err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("expected hedging to cut the latency, took %v", elapsed)
+		}
+		time.Sleep(50 * time.Millisecond)
+		if c := atomic.LoadInt32(&calls); c != 2 {
+			t.Errorf("expected the third copy never to start, got %d calls", c)
+		}
+		if c := atomic.LoadInt32(&cancelled); c != 1 {
+			t.Errorf("expected the slow copy to be cancelled, got %d", c)
+		}
+	})
+
+	t.Run("fast_first_call", func(t *testing.T) {
+		var calls int32
+		w := func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return 1, nil
+		}
+
+		if val, err := Hedge(20*time.Millisecond, w, 3)(context.Background()); err != nil || val != 1 {
+			t.Fatalf("expected 1, got %v, %v", val, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+		if c := atomic.LoadInt32(&calls); c != 1 {
+			t.Errorf("expected no hedged copies, got %d calls", c)
+		}
+	})
+
+	t.Run("keeps_worker_identity", func(t *testing.T) {
+		type identity struct {
+			index, total int
+			name         string
+		}
+		w := func(ctx context.Context) (identity, error) {
+			index, _ := IndexFromContext(ctx)
+			total, _ := TotalFromContext(ctx)
+			name, _ := NameFromContext(ctx)
+			return identity{index, total, name}, nil
+		}
+
+		plain := func(ctx context.Context) (identity, error) { return identity{}, nil }
+		results, err := NoRaceWith(context.Background(), []Option[identity]{WithNames[identity]("a", "b", "hedged")},
+			plain, plain, Hedge(time.Millisecond, w, 2))
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if got, want := results[2].Value, (identity{2, 3, "hedged"}); got != want {
+			t.Errorf("expected the hedged copies to see %+v, got %+v", want, got)
+		}
+	})
+}
+
+func TestWithHardCancel(t *testing.T) {
+	stubborn := func(ctx context.Context) (string, error) {
+		time.Sleep(100 * time.Millisecond)
+		return "late", nil
+	}
+
+	t.Run("returns_promptly", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := WithHardCancel(stubborn)(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 80*time.Millisecond {
+			t.Errorf("expected a prompt return, took %v", elapsed)
+		}
+	})
+
+	t.Run("race_with_stubborn_loser", func(t *testing.T) {
+		ctx := context.Background()
+		fast := func(ctx context.Context) (string, error) { return "fast", nil }
+
+		start := time.Now()
+		res, _ := Race(ctx, fast, WithHardCancel(stubborn))
+		if res.Value != "fast" {
+			t.Errorf("expected 'fast', got %v", res.Value)
+		}
+		if elapsed := time.Since(start); elapsed > 80*time.Millisecond {
+			t.Errorf("expected Race to return promptly, took %v", elapsed)
+		}
+	})
+
+	t.Run("late_result_delivered", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		late := make(chan Result[string], 1)
+		if _, err := WithHardCancelNotify(stubborn, late)(ctx); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		select {
+		case res := <-late:
+			if res.Value != "late" {
+				t.Errorf("expected 'late', got %v", res.Value)
+			}
+			if res.Index != IndexCancelled {
+				t.Errorf("expected IndexCancelled outside a batch, got %d", res.Index)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("expected the late result to be delivered")
+		}
+	})
+}